@@ -4,18 +4,31 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
+	"time"
 
 	"todo-wails-go/internal/adapter/db"
+	"todo-wails-go/internal/adapter/eventbus"
 	"todo-wails-go/internal/adapter/handler"
 	"todo-wails-go/internal/adapter/service"
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
 	"todo-wails-go/internal/usecase"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
+// trashPurgeInterval is how often expired tasks are purged from the trash
+const trashPurgeInterval = time.Hour
+
 // App struct
 type App struct {
-	ctx     context.Context
-	handler *handler.TaskHandler
+	ctx            context.Context
+	stopBackground context.CancelFunc
+	repo           ports.TaskRepository
+	handler        *handler.TaskHandler
+	projectHandler *handler.ProjectHandler
+	feedHandler    *handler.FeedHandler
 }
 
 // NewApp creates a new App application struct
@@ -26,34 +39,59 @@ func NewApp() *App {
 // startup is called when the app starts. The context is saved
 // so we can call the runtime methods
 func (a *App) startup(ctx context.Context) {
+	// Changes made through the window are attributed to the local user
+	ctx = domain.WithActor(ctx, service.LocalActor())
 	a.ctx = ctx
 
-	// Initialize database connection
-	connStr := os.Getenv("DATABASE_URL")
-	if connStr == "" {
-		// Default connection string for local development
-		connStr = "host=localhost port=5432 user=postgres password=postgres dbname=todo_app sslmode=disable"
-	}
-
 	// Create repository
-	repo, err := db.NewPostgresRepository(connStr)
-	if err != nil {
-		log.Printf("Warning: Failed to connect to PostgreSQL: %v", err)
-		log.Println("Using in-memory storage instead")
-		// For development, we'll use a simple in-memory storage
-		// In production, you should always use PostgreSQL
-		repo = db.NewMemoryRepository()
-	}
-	defer repo.Close()
+	repo := db.Open()
+	a.repo = repo
+
+	// Task changes and reminders are forwarded to every window as Wails runtime events
+	bus := eventbus.New()
+	bus.Subscribe(a.emitEvent)
 
 	// Create service
-	taskService := service.NewTaskService(repo)
+	opts := append(service.PoliciesFromEnv(), service.WithEventPublisher(bus))
+	taskService := service.NewTaskService(repo, opts...)
+
+	// Purge expired trash and deliver reminders in the background until
+	// shutdown; reminders missed while the app was closed go off right away
+	backgroundCtx, stopBackground := context.WithCancel(ctx)
+	a.stopBackground = stopBackground
+	go service.RunTrashPurge(backgroundCtx, taskService, trashPurgeInterval)
+	go service.RunReminders(backgroundCtx, taskService)
 
-	// Create use case
-	taskUseCase := usecase.NewTaskUseCase(taskService)
+	// Create use cases; projects share the task repository
+	projectService := service.NewProjectService(repo)
+	taskUseCase := usecase.NewTaskUseCase(taskService, projectService)
+	projectUseCase := usecase.NewProjectUseCase(projectService)
 
-	// Create handler
+	// Create handlers
 	a.handler = handler.NewTaskHandler(taskUseCase)
+	a.projectHandler = handler.NewProjectHandler(projectUseCase)
+
+	// Calendar feeds are served by cmd/server from the same store
+	feedService := service.NewFeedService(repo)
+	a.feedHandler = handler.NewFeedHandler(usecase.NewFeedUseCase(feedService))
+}
+
+// shutdown is called when the app is closing and releases the repository
+func (a *App) shutdown(ctx context.Context) {
+	if a.stopBackground != nil {
+		a.stopBackground()
+	}
+	if a.repo != nil {
+		if err := a.repo.Close(); err != nil {
+			log.Printf("Warning: Failed to close repository: %v", err)
+		}
+	}
+}
+
+// emitEvent forwards a domain event to the frontend under the event's type,
+// e.g. "task:created", with the event itself as the payload
+func (a *App) emitEvent(ctx context.Context, event models.Event) {
+	runtime.EventsEmit(a.ctx, string(event.Type), event)
 }
 
 // CreateTask creates a new task
@@ -72,7 +110,8 @@ func (a *App) GetTask(id string) (string, error) {
 	return a.handler.GetTask(a.ctx, id)
 }
 
-// GetTasks retrieves all tasks with optional filtering
+// GetTasks retrieves a page of tasks with optional filtering; set limit and
+// pass back nextCursor to load the following page
 func (a *App) GetTasks(filterJSON string) (string, error) {
 	if a.handler == nil {
 		return "", fmt.Errorf("database not initialized")
@@ -80,6 +119,15 @@ func (a *App) GetTasks(filterJSON string) (string, error) {
 	return a.handler.GetTasks(a.ctx, filterJSON)
 }
 
+// SearchTasks runs a full-text search, e.g. {"query": "login bug", "status": 0},
+// and returns tasks with relevance ranks and highlighted snippets
+func (a *App) SearchTasks(filterJSON string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.SearchTasks(a.ctx, filterJSON)
+}
+
 // UpdateTask updates an existing task
 func (a *App) UpdateTask(reqJSON string) (string, error) {
 	if a.handler == nil {
@@ -88,7 +136,15 @@ func (a *App) UpdateTask(reqJSON string) (string, error) {
 	return a.handler.UpdateTask(a.ctx, reqJSON)
 }
 
-// DeleteTask deletes a task by ID
+// PatchTask changes only the fields present in the request
+func (a *App) PatchTask(reqJSON string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.PatchTask(a.ctx, reqJSON)
+}
+
+// DeleteTask moves a task to the trash
 func (a *App) DeleteTask(id string) error {
 	if a.handler == nil {
 		return fmt.Errorf("database not initialized")
@@ -96,6 +152,30 @@ func (a *App) DeleteTask(id string) error {
 	return a.handler.DeleteTask(a.ctx, id)
 }
 
+// ListTrash retrieves the tasks in the trash, most recently deleted first
+func (a *App) ListTrash() (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.ListTrash(a.ctx)
+}
+
+// RestoreTask takes a task and the subtasks deleted with it out of the trash
+func (a *App) RestoreTask(id string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.RestoreTask(a.ctx, id)
+}
+
+// EmptyTrash permanently deletes every task in the trash and returns how many were deleted
+func (a *App) EmptyTrash() (int, error) {
+	if a.handler == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+	return a.handler.EmptyTrash(a.ctx)
+}
+
 // ToggleTaskStatus toggles the completion status of a task
 func (a *App) ToggleTaskStatus(id string) (string, error) {
 	if a.handler == nil {
@@ -120,10 +200,318 @@ func (a *App) GetTasksByPriority(priority int) (string, error) {
 	return a.handler.GetTasksByPriority(a.ctx, priority)
 }
 
-// GetOverdueTasks retrieves overdue tasks
+// GetOverdueTasks retrieves active tasks whose due date has passed
 func (a *App) GetOverdueTasks() (string, error) {
 	if a.handler == nil {
 		return "", fmt.Errorf("database not initialized")
 	}
 	return a.handler.GetOverdueTasks(a.ctx)
 }
+
+// GetTasksDueToday retrieves active tasks due today
+func (a *App) GetTasksDueToday() (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.GetTasksDueToday(a.ctx)
+}
+
+// GetTasksDueThisWeek retrieves active tasks due from today until Sunday
+func (a *App) GetTasksDueThisWeek() (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.GetTasksDueThisWeek(a.ctx)
+}
+
+// GetTasksByDateRange retrieves tasks due in [from, to), given as RFC 3339 or YYYY-MM-DD
+func (a *App) GetTasksByDateRange(from, to string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.GetTasksByDateRange(a.ctx, from, to)
+}
+
+// GetChildren retrieves the direct subtasks of a task
+func (a *App) GetChildren(id string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.GetChildren(a.ctx, id)
+}
+
+// GetSubtree retrieves a task with all of its subtasks and completion roll-up
+func (a *App) GetSubtree(id string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.GetSubtree(a.ctx, id)
+}
+
+// MoveTask moves a task under another parent or to the top level
+func (a *App) MoveTask(reqJSON string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.MoveTask(a.ctx, reqJSON)
+}
+
+// SetRecurrence sets or clears the RRULE-style recurrence of a task
+func (a *App) SetRecurrence(reqJSON string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.SetRecurrence(a.ctx, reqJSON)
+}
+
+// CreateTag creates a new tag
+func (a *App) CreateTag(reqJSON string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.CreateTag(a.ctx, reqJSON)
+}
+
+// GetTags retrieves all tags
+func (a *App) GetTags() (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.GetTags(a.ctx)
+}
+
+// UpdateTag renames or recolors a tag
+func (a *App) UpdateTag(reqJSON string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.UpdateTag(a.ctx, reqJSON)
+}
+
+// DeleteTag deletes a tag by ID
+func (a *App) DeleteTag(id string) error {
+	if a.handler == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return a.handler.DeleteTag(a.ctx, id)
+}
+
+// AddTagToTask attaches a tag to a task, creating the tag if needed
+func (a *App) AddTagToTask(taskID string, tagName string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.AddTagToTask(a.ctx, taskID, tagName)
+}
+
+// RemoveTagFromTask detaches a tag from a task
+func (a *App) RemoveTagFromTask(taskID string, tagID string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.RemoveTagFromTask(a.ctx, taskID, tagID)
+}
+
+// GetTasksByTags retrieves tasks carrying at least one of the given tags
+func (a *App) GetTasksByTags(tags []string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.GetTasksByTags(a.ctx, tags)
+}
+
+// Undo reverts the most recent task mutation of this session, restoring the
+// exact earlier state of every task it changed
+func (a *App) Undo() (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.Undo(a.ctx)
+}
+
+// Redo applies the most recently undone task mutation again
+func (a *App) Redo() (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.Redo(a.ctx)
+}
+
+// History lists the task mutations of this session that can be undone and redone
+func (a *App) History() (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.History(a.ctx)
+}
+
+// GetTaskHistory returns the audit log of a task: every change made to its
+// fields, who made it and when, oldest first
+func (a *App) GetTaskHistory(id string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.GetTaskHistory(a.ctx, id)
+}
+
+// ExportICS returns the tasks matching the filter as an iCalendar (.ics) file
+// that calendar apps can open; an empty filter exports every task
+func (a *App) ExportICS(filterJSON string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.ExportICS(a.ctx, filterJSON)
+}
+
+// ImportICS creates tasks from the VTODOs of an iCalendar file and reports
+// how many were created and skipped
+func (a *App) ImportICS(data string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.ImportICS(a.ctx, data)
+}
+
+// ExportTasks returns the tasks matching the filter as a "csv" or "jsonl"
+// (JSON Lines) file for backups, together with the matching tasks in the
+// trash and the projects and tags; an empty filter exports every task
+func (a *App) ExportTasks(format, filterJSON string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.ExportTasks(a.ctx, format, filterJSON)
+}
+
+// ImportTasks imports a "csv" or "jsonl" file. The options choose what
+// happens to tasks whose ID already exists and can ask for a dry run that
+// only reports what would change: {"strategy": "newest", "dryRun": true}
+func (a *App) ImportTasks(format, data, optionsJSON string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.ImportTasks(a.ctx, format, data, optionsJSON)
+}
+
+// ExportTodoTxt returns the tasks matching the filter as a todo.txt file;
+// an empty filter exports every task
+func (a *App) ExportTodoTxt(filterJSON string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.ExportTodoTxt(a.ctx, filterJSON)
+}
+
+// ImportTodoTxt creates tasks from the lines of a todo.txt file and reports
+// how many were created
+func (a *App) ImportTodoTxt(data string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.ImportTodoTxt(a.ctx, data)
+}
+
+// GetReminders returns the reminders of a task, earliest first
+func (a *App) GetReminders(taskID string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.GetReminders(a.ctx, taskID)
+}
+
+// SetReminders replaces the reminders of a task, given as minutes before the
+// due date, e.g. {"taskId": "...", "offsets": [1440, 15]}
+func (a *App) SetReminders(reqJSON string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.SetReminders(a.ctx, reqJSON)
+}
+
+// SnoozeReminder makes a reminder go off again later: {"id": "...", "minutes": 10}
+func (a *App) SnoozeReminder(reqJSON string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.SnoozeReminder(a.ctx, reqJSON)
+}
+
+// DismissReminder silences a reminder until the due date of its task moves
+func (a *App) DismissReminder(id string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.DismissReminder(a.ctx, id)
+}
+
+// GetTasksByProject retrieves the tasks of a project; an empty ID selects tasks without a project
+func (a *App) GetTasksByProject(projectID string) (string, error) {
+	if a.handler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.handler.GetTasksByProject(a.ctx, projectID)
+}
+
+// CreateProject creates a new project
+func (a *App) CreateProject(reqJSON string) (string, error) {
+	if a.projectHandler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.projectHandler.CreateProject(a.ctx, reqJSON)
+}
+
+// GetProject retrieves a project by ID
+func (a *App) GetProject(id string) (string, error) {
+	if a.projectHandler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.projectHandler.GetProject(a.ctx, id)
+}
+
+// GetProjects retrieves all projects with their task counts
+func (a *App) GetProjects(includeArchived bool) (string, error) {
+	if a.projectHandler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.projectHandler.GetProjects(a.ctx, includeArchived)
+}
+
+// UpdateProject updates an existing project
+func (a *App) UpdateProject(reqJSON string) (string, error) {
+	if a.projectHandler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.projectHandler.UpdateProject(a.ctx, reqJSON)
+}
+
+// DeleteProject deletes a project; its tasks are kept without a project
+func (a *App) DeleteProject(id string) error {
+	if a.projectHandler == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return a.projectHandler.DeleteProject(a.ctx, id)
+}
+
+// CreateFeed saves a filter as a calendar feed, e.g. {"name": "Due", "filter": {"hasDueDate": true}};
+// the returned token is the secret part of the feed URL and is not shown again
+func (a *App) CreateFeed(reqJSON string) (string, error) {
+	if a.feedHandler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.feedHandler.CreateFeed(a.ctx, reqJSON)
+}
+
+// GetFeeds retrieves all calendar feeds, without their tokens
+func (a *App) GetFeeds() (string, error) {
+	if a.feedHandler == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	return a.feedHandler.GetFeeds(a.ctx)
+}
+
+// DeleteFeed deletes a calendar feed; its URL stops working
+func (a *App) DeleteFeed(id string) error {
+	if a.feedHandler == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return a.feedHandler.DeleteFeed(a.ctx, id)
+}
@@ -0,0 +1,141 @@
+// Command migrate applies, reverts and inspects the database schema migrations.
+//
+//	migrate [-driver postgres|sqlite] [-dsn connection] up
+//	migrate [-driver postgres|sqlite] [-dsn connection] down [steps]
+//	migrate [-driver postgres|sqlite] [-dsn connection] status
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"todo-wails-go/internal/adapter/db"
+	"todo-wails-go/internal/adapter/db/migrations"
+
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+func main() {
+	driver := flag.String("driver", "", `"postgres" or "sqlite" (default: postgres when DATABASE_URL is set, sqlite otherwise)`)
+	dsn := flag.String("dsn", "", "Postgres connection string or SQLite file path (default: DATABASE_URL or the app's SQLite file)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] up | down [steps] | status\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*driver, *dsn, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(driver, dsn string, args []string) error {
+	if len(args) == 0 {
+		flag.Usage()
+		return fmt.Errorf("missing command")
+	}
+
+	conn, dialect, err := open(driver, dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	migrator, err := migrations.New(conn, dialect)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("applied  %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Printf("reverted %04d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// open connects to the database the same way the app would pick it
+func open(driver, dsn string) (*sql.DB, migrations.Dialect, error) {
+	if driver == "" {
+		driver = "sqlite"
+		if os.Getenv("DATABASE_URL") != "" || dsn != "" {
+			driver = "postgres"
+		}
+	}
+
+	switch driver {
+	case "postgres":
+		if dsn == "" {
+			dsn = os.Getenv("DATABASE_URL")
+		}
+		if dsn == "" {
+			return nil, "", fmt.Errorf("no Postgres connection string, use -dsn or DATABASE_URL")
+		}
+		conn, err := sql.Open("postgres", dsn)
+		return conn, migrations.Postgres, err
+
+	case "sqlite":
+		if dsn == "" {
+			dsn = os.Getenv("TODO_SQLITE_PATH")
+		}
+		if dsn == "" {
+			var err error
+			if dsn, err = db.DefaultSQLitePath(); err != nil {
+				return nil, "", err
+			}
+		}
+		conn, err := sql.Open("sqlite", db.SQLiteDSN(dsn))
+		return conn, migrations.SQLite, err
+
+	default:
+		return nil, "", fmt.Errorf("unknown driver %q", driver)
+	}
+}
@@ -0,0 +1,76 @@
+// Command server runs the task REST API without the desktop GUI, on the same
+// task store the app would use.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"todo-wails-go/internal/adapter/db"
+	"todo-wails-go/internal/adapter/httpapi"
+	"todo-wails-go/internal/adapter/service"
+	"todo-wails-go/internal/usecase"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8080", "address to listen on")
+	token := flag.String("token", os.Getenv("TODO_API_TOKEN"), "bearer token API clients must send (default $TODO_API_TOKEN)")
+	flag.Parse()
+
+	if *token == "" && !isLoopback(*addr) {
+		log.Printf("Warning: Serving the API without a token on %s; anyone who can reach it can change every task", *addr)
+	}
+
+	repo := db.Open()
+	defer repo.Close()
+
+	taskService := service.NewTaskService(repo, service.PoliciesFromEnv()...)
+	feedService := service.NewFeedService(repo)
+	projectService := service.NewProjectService(repo)
+	api := httpapi.NewServer(usecase.NewTaskUseCase(taskService, projectService), usecase.NewFeedUseCase(feedService),
+		httpapi.WithToken(*token))
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           api,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go service.RunTrashPurge(ctx, taskService, time.Hour)
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Listening on %s", *addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Server failed: %v", err)
+	}
+}
+
+// isLoopback reports whether a listen address only accepts local connections
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
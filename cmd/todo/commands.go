@@ -0,0 +1,376 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/usecase"
+)
+
+// cli carries what every command needs
+type cli struct {
+	useCase *usecase.TaskUseCase
+	out     io.Writer
+	json    bool
+}
+
+// options holds the parsed flags and positional arguments of a command
+type options struct {
+	args []string
+
+	json    bool
+	verbose bool
+
+	// add
+	priority    string
+	due         string
+	description string
+	tags        string
+	project     string
+
+	// ls
+	overdue   bool
+	today     bool
+	week      bool
+	all       bool
+	done      bool
+	sortBy    string
+	sortDesc  bool
+	tagFilter string
+}
+
+// command is one CLI subcommand
+type command struct {
+	args  int // required positional arguments, -1 for any number
+	flags func(fs *flag.FlagSet, o *options)
+	run   func(ctx context.Context, c *cli, o *options) error
+}
+
+var commands = map[string]*command{
+	"add": {
+		args: 1,
+		flags: func(fs *flag.FlagSet, o *options) {
+			fs.StringVar(&o.priority, "priority", "medium", "low, medium or high")
+			fs.StringVar(&o.due, "due", "", `due date: today, tomorrow, a weekday, +3d, +2w, 2026-11-01 or "2026-11-01 15:04"`)
+			fs.StringVar(&o.description, "desc", "", "description")
+			fs.StringVar(&o.tags, "tag", "", "comma-separated tags")
+			fs.StringVar(&o.project, "project", "", "project ID")
+		},
+		run: runAdd,
+	},
+	"ls": {
+		args: -1,
+		flags: func(fs *flag.FlagSet, o *options) {
+			fs.BoolVar(&o.overdue, "overdue", false, "only active tasks whose due date has passed")
+			fs.BoolVar(&o.today, "today", false, "only active tasks due today")
+			fs.BoolVar(&o.week, "week", false, "only active tasks due from today until Sunday")
+			fs.BoolVar(&o.all, "all", false, "include completed tasks")
+			fs.BoolVar(&o.done, "done", false, "only completed tasks")
+			fs.StringVar(&o.priority, "priority", "", "low, medium or high")
+			fs.StringVar(&o.tagFilter, "tag", "", "comma-separated tags, any of them matches")
+			fs.StringVar(&o.project, "project", "", "project ID")
+			fs.StringVar(&o.sortBy, "sort", "created_at", "comma-separated keys such as priority:desc,due_date; fields: created_at, updated_at, due_date, priority, status, title")
+			fs.BoolVar(&o.sortDesc, "desc-order", false, "sort keys without a direction in descending order")
+		},
+		run: runList,
+	},
+	"show": {args: 1, run: runShow},
+	"done": {args: 1, run: runDone},
+	"rm":   {args: 1, run: runRemove},
+
+	"trash":       {args: 0, run: runTrash},
+	"restore":     {args: 1, run: runRestore},
+	"empty-trash": {args: 0, run: runEmptyTrash},
+}
+
+// parse reads flags that may appear before, between or after positional arguments
+func (cmd *command) parse(args []string) (*options, error) {
+	o := &options{}
+	fs := flag.NewFlagSet("todo", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.BoolVar(&o.json, "json", false, "print JSON")
+	fs.BoolVar(&o.verbose, "verbose", false, "show storage logs")
+	if cmd.flags != nil {
+		cmd.flags(fs, o)
+	}
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		o.args = append(o.args, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if cmd.args >= 0 && len(o.args) != cmd.args {
+		return nil, fmt.Errorf("expected %d argument(s), got %d", cmd.args, len(o.args))
+	}
+
+	return o, nil
+}
+
+func runAdd(ctx context.Context, c *cli, o *options) error {
+	priority, err := parsePriority(o.priority)
+	if err != nil {
+		return err
+	}
+
+	req := &models.CreateTaskRequest{
+		Title:       o.args[0],
+		Description: o.description,
+		Priority:    priority,
+		Tags:        splitList(o.tags),
+	}
+
+	if o.due != "" {
+		due, err := parseDue(o.due, time.Now())
+		if err != nil {
+			return err
+		}
+		req.DueDate = &due
+	}
+
+	if o.project != "" {
+		req.ProjectID = &o.project
+	}
+
+	task, err := c.useCase.CreateTask(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printJSON(task)
+	}
+	fmt.Fprintf(c.out, "Added %s %s\n", shortID(task.ID), task.Title)
+	return nil
+}
+
+func runList(ctx context.Context, c *cli, o *options) error {
+	var tasks []*models.Task
+	var err error
+
+	switch {
+	case o.overdue:
+		tasks, err = c.useCase.GetOverdueTasks(ctx)
+	case o.today:
+		tasks, err = c.useCase.GetTasksDueToday(ctx)
+	case o.week:
+		tasks, err = c.useCase.GetTasksDueThisWeek(ctx)
+	default:
+		filter := &models.FilterOptions{
+			Sort:       models.ParseSort(o.sortBy),
+			AnyTags:    splitList(o.tagFilter),
+			Expression: strings.Join(o.args, " "),
+		}
+		if o.sortDesc {
+			for i := range filter.Sort {
+				if filter.Sort[i].Direction == "" {
+					filter.Sort[i].Direction = models.SortDesc
+				}
+			}
+		}
+
+		// A query decides about the status itself unless --done is given
+		switch {
+		case o.done:
+			status := models.StatusCompleted
+			filter.Status = &status
+		case !o.all && filter.Expression == "":
+			status := models.StatusActive
+			filter.Status = &status
+		}
+
+		if o.priority != "" {
+			priority, err := parsePriority(o.priority)
+			if err != nil {
+				return err
+			}
+			filter.Priority = &priority
+		}
+
+		if o.project != "" {
+			filter.ProjectID = &o.project
+		}
+
+		tasks, err = c.useCase.GetTasks(ctx, filter)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		if tasks == nil {
+			tasks = []*models.Task{}
+		}
+		return c.printJSON(tasks)
+	}
+	return printTable(c.out, tasks)
+}
+
+func runShow(ctx context.Context, c *cli, o *options) error {
+	task, err := c.resolve(ctx, o.args[0])
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printJSON(task)
+	}
+	printTask(c.out, task)
+	return nil
+}
+
+func runDone(ctx context.Context, c *cli, o *options) error {
+	task, err := c.resolve(ctx, o.args[0])
+	if err != nil {
+		return err
+	}
+
+	if task.Status != models.StatusCompleted {
+		task, err = c.useCase.ToggleTaskStatus(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.json {
+		return c.printJSON(task)
+	}
+	fmt.Fprintf(c.out, "Completed %s %s\n", shortID(task.ID), task.Title)
+	return nil
+}
+
+func runRemove(ctx context.Context, c *cli, o *options) error {
+	task, err := c.resolve(ctx, o.args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := c.useCase.DeleteTask(ctx, task.ID); err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printJSON(map[string]string{"deleted": task.ID})
+	}
+	fmt.Fprintf(c.out, "Moved %s %s to the trash\n", shortID(task.ID), task.Title)
+	return nil
+}
+
+func runTrash(ctx context.Context, c *cli, o *options) error {
+	tasks, err := c.useCase.ListTrash(ctx)
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		if tasks == nil {
+			tasks = []*models.Task{}
+		}
+		return c.printJSON(tasks)
+	}
+	return printTable(c.out, tasks)
+}
+
+func runRestore(ctx context.Context, c *cli, o *options) error {
+	trash, err := c.useCase.ListTrash(ctx)
+	if err != nil {
+		return err
+	}
+	task, err := resolveIn(trash, o.args[0])
+	if err != nil {
+		return err
+	}
+
+	task, err = c.useCase.RestoreTask(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printJSON(task)
+	}
+	fmt.Fprintf(c.out, "Restored %s %s\n", shortID(task.ID), task.Title)
+	return nil
+}
+
+func runEmptyTrash(ctx context.Context, c *cli, o *options) error {
+	n, err := c.useCase.EmptyTrash(ctx)
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printJSON(map[string]int{"purged": n})
+	}
+	fmt.Fprintf(c.out, "Deleted %d task(s) for good\n", n)
+	return nil
+}
+
+// resolve finds the task whose ID is or starts with prefix, like git short hashes
+func (c *cli) resolve(ctx context.Context, prefix string) (*models.Task, error) {
+	tasks, err := c.useCase.GetTasks(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resolveIn(tasks, prefix)
+}
+
+// resolveIn finds the task whose ID is or starts with prefix among tasks
+func resolveIn(tasks []*models.Task, prefix string) (*models.Task, error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if prefix == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+
+	var matches []*models.Task
+	for _, task := range tasks {
+		if task.ID == prefix {
+			return task, nil
+		}
+		if strings.HasPrefix(task.ID, prefix) {
+			matches = append(matches, task)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no task matches %q", prefix)
+	case 1:
+		return matches[0], nil
+	}
+
+	candidates := make([]string, len(matches))
+	for i, task := range matches {
+		candidates[i] = shortID(task.ID) + " " + task.Title
+	}
+	return nil, fmt.Errorf("%q is ambiguous, candidates:\n  %s", prefix, strings.Join(candidates, "\n  "))
+}
+
+// printJSON writes v as indented JSON
+func (c *cli) printJSON(v interface{}) error {
+	encoder := json.NewEncoder(c.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// splitList splits a comma-separated flag value, ignoring empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
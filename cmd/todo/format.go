@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// shortIDLength is how many ID characters the table shows
+const shortIDLength = 8
+
+var priorityNames = map[models.Priority]string{
+	models.PriorityLow:    "low",
+	models.PriorityMedium: "medium",
+	models.PriorityHigh:   "high",
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// parsePriority accepts low/medium/high or 0/1/2
+func parsePriority(value string) (models.Priority, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for priority, name := range priorityNames {
+		if value == name || value == strconv.Itoa(int(priority)) {
+			return priority, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid priority %q, expected low, medium or high", value)
+}
+
+// parseDue understands today, tomorrow, weekday names (the next such day after
+// today), +Nd / +Nw offsets and ISO dates. Dates without a time are due at the end of the day.
+func parseDue(value string, now time.Time) (time.Time, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	year, month, day := now.Date()
+	endOfDay := func(offset int) time.Time {
+		return time.Date(year, month, day+offset, 23, 59, 0, 0, now.Location())
+	}
+
+	switch value {
+	case "today":
+		return endOfDay(0), nil
+	case "tomorrow":
+		return endOfDay(1), nil
+	}
+
+	if weekday, ok := weekdays[value]; ok {
+		offset := (int(weekday) - int(now.Weekday()) + 7) % 7
+		if offset == 0 {
+			offset = 7
+		}
+		return endOfDay(offset), nil
+	}
+
+	if strings.HasPrefix(value, "+") && len(value) > 2 {
+		n, err := strconv.Atoi(value[1 : len(value)-1])
+		if err == nil && n >= 0 {
+			switch value[len(value)-1] {
+			case 'd':
+				return endOfDay(n), nil
+			case 'w':
+				return endOfDay(7 * n), nil
+			}
+		}
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t.Add(23*time.Hour + 59*time.Minute), nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02t15:04"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(value)); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid due date %q", value)
+}
+
+// shortID abbreviates a task ID for display
+func shortID(id string) string {
+	if len(id) > shortIDLength {
+		return id[:shortIDLength]
+	}
+	return id
+}
+
+// printTable writes tasks as an aligned table
+func printTable(out io.Writer, tasks []*models.Task) error {
+	if len(tasks) == 0 {
+		fmt.Fprintln(out, "No tasks.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\t \tPRIORITY\tDUE\tTITLE\tTAGS")
+	for _, task := range tasks {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			shortID(task.ID), statusMark(task), priorityNames[task.Priority],
+			formatDue(task), task.Title, tagList(task))
+	}
+	return w.Flush()
+}
+
+// printTask writes the details of one task
+func printTask(out io.Writer, task *models.Task) {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "ID:\t%s\n", task.ID)
+	fmt.Fprintf(w, "Title:\t%s\n", task.Title)
+	if task.Description != "" {
+		fmt.Fprintf(w, "Description:\t%s\n", task.Description)
+	}
+	fmt.Fprintf(w, "Status:\t%s\n", map[models.Status]string{models.StatusActive: "active", models.StatusCompleted: "completed"}[task.Status])
+	fmt.Fprintf(w, "Priority:\t%s\n", priorityNames[task.Priority])
+	fmt.Fprintf(w, "Due:\t%s\n", formatDue(task))
+	if len(task.Tags) > 0 {
+		fmt.Fprintf(w, "Tags:\t%s\n", tagList(task))
+	}
+	if task.Recurrence != nil {
+		fmt.Fprintf(w, "Repeats:\t%s\n", task.Recurrence.String())
+	}
+	fmt.Fprintf(w, "Created:\t%s\n", task.CreatedAt.Local().Format("2006-01-02 15:04"))
+	fmt.Fprintf(w, "Updated:\t%s\n", task.UpdatedAt.Local().Format("2006-01-02 15:04"))
+	w.Flush()
+}
+
+func statusMark(task *models.Task) string {
+	if task.Status == models.StatusCompleted {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+func formatDue(task *models.Task) string {
+	if task.DueDate == nil {
+		return "-"
+	}
+	due := task.DueDate.Local().Format("Mon 2006-01-02 15:04")
+	if task.Status == models.StatusActive && task.DueDate.Before(time.Now()) {
+		due += " (overdue)"
+	}
+	return due
+}
+
+func tagList(task *models.Task) string {
+	names := make([]string, len(task.Tags))
+	for i, tag := range task.Tags {
+		names[i] = tag.Name
+	}
+	return strings.Join(names, ",")
+}
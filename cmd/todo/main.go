@@ -0,0 +1,92 @@
+// Command todo is a terminal client for the task store used by the desktop app.
+//
+//	todo add "Ship release" --priority high --due friday --tag release
+//	todo ls [--overdue] [--all | --done] [--tag name] [--json]
+//	todo show <id>
+//	todo done <id>
+//	todo rm <id>
+//	todo trash
+//	todo restore <id>
+//
+// Task IDs may be shortened to any unique prefix.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"todo-wails-go/internal/adapter/db"
+	"todo-wails-go/internal/adapter/service"
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/usecase"
+)
+
+const usage = `Usage: todo <command> [arguments]
+
+Commands:
+  add <title>   create a task (--priority, --due, --desc, --tag, --project)
+  ls [query]    list tasks (--overdue, --today, --week, --all, --done, --priority, --tag, --project, --sort, --desc-order)
+  show <id>     show one task
+  done <id>     mark a task as completed
+  rm <id>       move a task to the trash
+  trash         list the tasks in the trash
+  restore <id>  take a task out of the trash
+  empty-trash   delete the tasks in the trash for good
+
+Every command accepts --json for machine-readable output and --verbose for storage logs.
+IDs can be abbreviated to any unique prefix.
+
+A query lists all matching tasks, completed ones included, e.g.
+  todo ls priority:high status:active due:<2026-11-01 tag:backend '"login bug"'
+Terms: priority:[<|<=|>|>=]low|medium|high, status:active|completed, due:, created:, updated:
+with [op]YYYY-MM-DD (due:none), tag:name|none, project:id|none, words and "phrases".
+Terms are joined by AND unless separated by OR; NOT negates a term, parentheses group.
+`
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "--help" || os.Args[1] == "help" {
+		fmt.Print(usage)
+		return
+	}
+
+	if err := run(os.Args[1], os.Args[2:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "todo:", err)
+		os.Exit(1)
+	}
+}
+
+func run(command string, args []string, out io.Writer) error {
+	cmd, ok := commands[command]
+	if !ok {
+		return fmt.Errorf("unknown command %q, see 'todo help'", command)
+	}
+
+	opts, err := cmd.parse(args)
+	if err != nil {
+		return err
+	}
+
+	if !opts.verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	repo := db.Open()
+	defer repo.Close()
+
+	if _, inMemory := repo.(*db.MemoryRepository); inMemory {
+		fmt.Fprintln(os.Stderr, "todo: warning: no database available, changes will not be saved")
+	}
+
+	taskService := service.NewTaskService(repo, service.PoliciesFromEnv()...)
+	c := &cli{
+		useCase: usecase.NewTaskUseCase(taskService, service.NewProjectService(repo)),
+		out:     out,
+		json:    opts.json,
+	}
+
+	ctx := domain.WithActor(context.Background(), service.LocalActor())
+	return cmd.run(ctx, c, opts)
+}
@@ -9,11 +9,19 @@ import {
     ToggleTaskStatus,
     GetTasksByStatus,
     GetTasksByPriority,
-    GetOverdueTasks
+    GetOverdueTasks,
+    Undo,
+    Redo,
+    SnoozeReminder,
+    DismissReminder
 } from '../wailsjs/go/main/App';
+import { EventsOn } from '../wailsjs/runtime/runtime';
 
 // Global state
+const PAGE_SIZE = 50;
 let tasks = [];
+let nextCursor = null;
+let totalTasks = 0;
 let currentFilter = {
     status: null,
     priority: null,
@@ -149,7 +157,7 @@ function renderApp() {
                     <h3 class="modal-title">Delete Task</h3>
                     <button class="modal-close" onclick="closeModal()">&times;</button>
                 </div>
-                <p>Are you sure you want to delete this task? This action cannot be undone.</p>
+                <p>Are you sure you want to delete this task? It will be moved to the trash.</p>
                 <div class="modal-actions">
                     <button class="btn btn-secondary" onclick="closeModal()">Cancel</button>
                     <button class="btn btn-danger" onclick="confirmDelete()">Delete</button>
@@ -168,14 +176,115 @@ function setupEventListeners() {
     document.getElementById('priority-filter').addEventListener('change', applyFilters);
     document.getElementById('sort-filter').addEventListener('change', applyFilters);
     document.getElementById('order-filter').addEventListener('change', applyFilters);
+
+    // Ctrl+Z / Ctrl+Shift+Z (or Ctrl+Y) undo and redo task changes, except while typing
+    document.addEventListener('keydown', handleHistoryKeys);
+
+    // The backend announces every task change, including those made in other windows
+    EventsOn('task:created', event => upsertTask(event.task));
+    EventsOn('task:updated', event => upsertTask(event.task));
+    EventsOn('task:statusChanged', event => upsertTask(event.task));
+    EventsOn('task:deleted', event => removeTask(event.taskId));
+    EventsOn('reminder:fired', showReminder);
+}
+
+// showReminder shows a reminder that went off until it is snoozed or dismissed
+function showReminder(event) {
+    let stack = document.getElementById('reminders');
+    if (!stack) {
+        stack = document.createElement('div');
+        stack.id = 'reminders';
+        stack.className = 'reminders';
+        document.body.appendChild(stack);
+    }
+
+    const due = event.task.dueDate ? ` is due ${formatDate(event.task.dueDate)}` : '';
+    const element = document.createElement('div');
+    element.className = 'notification info';
+    element.innerHTML = `
+        <div>⏰ ${escapeHtml(event.task.title)}${due}</div>
+        <div class="notification-actions">
+            <button class="btn btn-sm btn-secondary" data-action="snooze">Snooze 10 min</button>
+            <button class="btn btn-sm btn-secondary" data-action="dismiss">Dismiss</button>
+        </div>
+    `;
+    element.querySelector('[data-action="snooze"]').onclick = () =>
+        closeReminder(element, SnoozeReminder(JSON.stringify({ id: event.reminder.id, minutes: 10 })));
+    element.querySelector('[data-action="dismiss"]').onclick = () =>
+        closeReminder(element, DismissReminder(event.reminder.id));
+    stack.appendChild(element);
+}
+
+// closeReminder removes a reminder once the snooze or dismiss call succeeds
+async function closeReminder(element, call) {
+    try {
+        await call;
+        element.remove();
+    } catch (error) {
+        console.error('Error updating reminder:', error);
+        showNotification('Error updating reminder', 'error');
+    }
+}
+
+// upsertTask puts a created or changed task into the loaded list
+function upsertTask(task) {
+    const index = tasks.findIndex(t => t.id === task.id);
+    if (index !== -1) {
+        tasks[index] = task;
+    } else {
+        tasks.unshift(task);
+        totalTasks++;
+    }
+    renderTasks();
+}
+
+// removeTask drops a deleted task from the loaded list
+function removeTask(taskId) {
+    const count = tasks.length;
+    tasks = tasks.filter(t => t.id !== taskId);
+    totalTasks -= count - tasks.length;
+    renderTasks();
+}
+
+function handleHistoryKeys(e) {
+    if (!(e.ctrlKey || e.metaKey) || e.target.closest('input, textarea, select')) {
+        return;
+    }
+    const key = e.key.toLowerCase();
+    if (key === 'z') {
+        e.preventDefault();
+        stepHistory(e.shiftKey ? Redo : Undo, e.shiftKey ? 'Redo' : 'Undo');
+    } else if (key === 'y') {
+        e.preventDefault();
+        stepHistory(Redo, 'Redo');
+    }
+}
+
+// stepHistory undoes or redoes one change and reloads the list
+async function stepHistory(step, label) {
+    try {
+        const entry = JSON.parse(await step());
+        await loadTasks();
+        showNotification(`${label}: ${entry.action} "${entry.title}"`, 'success');
+    } catch (error) {
+        console.error(`${label} failed:`, error);
+        showNotification(`${label} failed`, 'error');
+    }
 }
 
 // Task management functions
-async function loadTasks() {
+// loadTasks fetches the first page of tasks, or the next one when append is set
+async function loadTasks(append = false) {
     try {
-        const filterJSON = JSON.stringify(currentFilter);
-        const result = await GetTasks(filterJSON);
-        tasks = JSON.parse(result);
+        const filter = { ...currentFilter, limit: PAGE_SIZE };
+        if (append && nextCursor) {
+            filter.cursor = nextCursor;
+        }
+        const result = await GetTasks(JSON.stringify(filter));
+        const page = JSON.parse(result);
+        tasks = append ? tasks.concat(page.items) : page.items;
+        nextCursor = page.nextCursor || null;
+        totalTasks = page.total;
         renderTasks();
     } catch (error) {
         console.error('Error loading tasks:', error);
@@ -212,7 +321,8 @@ async function handleTaskSubmit(e) {
                 description,
                 priority,
                 status: editingTask.status,
-                dueDate: dueDate ? new Date(dueDate).toISOString() : null
+                dueDate: dueDate ? new Date(dueDate).toISOString() : null,
+                version: editingTask.version
             };
             
             const result = await UpdateTask(JSON.stringify(updateData));
@@ -229,8 +339,7 @@ async function handleTaskSubmit(e) {
         } else {
             // Create new task
             const result = await CreateTask(JSON.stringify(taskData));
-            const newTask = JSON.parse(result);
-            tasks.unshift(newTask);
+            upsertTask(JSON.parse(result));
             showNotification('Task created successfully', 'success');
         }
         
@@ -241,10 +350,31 @@ async function handleTaskSubmit(e) {
         
     } catch (error) {
         console.error('Error saving task:', error);
+        const payload = parseError(error);
+        if (payload && payload.code === 'conflict' && payload.current) {
+            // Someone else changed the task: show their version and keep the form open
+            const index = tasks.findIndex(t => t.id === payload.current.id);
+            if (index !== -1) {
+                tasks[index] = payload.current;
+            }
+            editingTask = payload.current;
+            renderTasks();
+            showNotification('Task was changed elsewhere, review and save again', 'error');
+            return;
+        }
         showNotification('Error saving task', 'error');
     }
 }
 
+// parseError decodes the structured {code, message, field} error of a backend call
+function parseError(error) {
+    try {
+        return JSON.parse(typeof error === 'string' ? error : error.message);
+    } catch {
+        return null;
+    }
+}
+
 async function toggleTaskStatus(taskId) {
     try {
         const result = await ToggleTaskStatus(taskId);
@@ -342,7 +472,11 @@ function renderTasks() {
                 </div>
             </div>
         </div>
-    `).join('');
+    `).join('') + (nextCursor ? `
+        <button class="btn btn-secondary load-more" onclick="loadTasks(true)">
+            Load more (${tasks.length} of ${totalTasks})
+        </button>
+    ` : '');
 }
 
 function getFilteredTasks() {
@@ -403,7 +537,7 @@ function applyFilters() {
         sortOrder: orderFilter
     };
     
-    renderTasks();
+    loadTasks();
 }
 
 function clearFilters() {
@@ -419,7 +553,7 @@ function clearFilters() {
         sortOrder: 'desc'
     };
     
-    renderTasks();
+    loadTasks();
 }
 
 // Modal functions
@@ -529,3 +663,4 @@ window.closeModal = closeModal;
 window.confirmDelete = confirmDelete;
 window.toggleTheme = toggleTheme;
 window.clearFilters = clearFilters;
+window.loadTasks = loadTasks;
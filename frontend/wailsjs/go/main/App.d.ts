@@ -1,20 +1,104 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddTagToTask(arg1:string,arg2:string):Promise<string>;
+
+export function CreateFeed(arg1:string):Promise<string>;
+
+export function CreateProject(arg1:string):Promise<string>;
+
+export function CreateTag(arg1:string):Promise<string>;
+
 export function CreateTask(arg1:string):Promise<string>;
 
+export function DeleteFeed(arg1:string):Promise<void>;
+
+export function DeleteProject(arg1:string):Promise<void>;
+
+export function DeleteTag(arg1:string):Promise<void>;
+
 export function DeleteTask(arg1:string):Promise<void>;
 
+export function DismissReminder(arg1:string):Promise<string>;
+
+export function EmptyTrash():Promise<number>;
+
+export function ExportICS(arg1:string):Promise<string>;
+
+export function ExportTasks(arg1:string,arg2:string):Promise<string>;
+
+export function ExportTodoTxt(arg1:string):Promise<string>;
+
+export function GetChildren(arg1:string):Promise<string>;
+
+export function GetFeeds():Promise<string>;
+
 export function GetOverdueTasks():Promise<string>;
 
+export function GetProject(arg1:string):Promise<string>;
+
+export function GetProjects(arg1:boolean):Promise<string>;
+
+export function GetReminders(arg1:string):Promise<string>;
+
+export function GetSubtree(arg1:string):Promise<string>;
+
+export function GetTags():Promise<string>;
+
 export function GetTask(arg1:string):Promise<string>;
 
+export function GetTaskHistory(arg1:string):Promise<string>;
+
 export function GetTasks(arg1:string):Promise<string>;
 
+export function GetTasksByDateRange(arg1:string,arg2:string):Promise<string>;
+
 export function GetTasksByPriority(arg1:number):Promise<string>;
 
+export function GetTasksByProject(arg1:string):Promise<string>;
+
 export function GetTasksByStatus(arg1:number):Promise<string>;
 
+export function GetTasksByTags(arg1:Array<string>):Promise<string>;
+
+export function GetTasksDueThisWeek():Promise<string>;
+
+export function GetTasksDueToday():Promise<string>;
+
+export function History():Promise<string>;
+
+export function ImportICS(arg1:string):Promise<string>;
+
+export function ImportTasks(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function ImportTodoTxt(arg1:string):Promise<string>;
+
+export function ListTrash():Promise<string>;
+
+export function MoveTask(arg1:string):Promise<string>;
+
+export function PatchTask(arg1:string):Promise<string>;
+
+export function Redo():Promise<string>;
+
+export function RemoveTagFromTask(arg1:string,arg2:string):Promise<string>;
+
+export function RestoreTask(arg1:string):Promise<string>;
+
+export function SearchTasks(arg1:string):Promise<string>;
+
+export function SetRecurrence(arg1:string):Promise<string>;
+
+export function SetReminders(arg1:string):Promise<string>;
+
+export function SnoozeReminder(arg1:string):Promise<string>;
+
 export function ToggleTaskStatus(arg1:string):Promise<string>;
 
+export function Undo():Promise<string>;
+
+export function UpdateProject(arg1:string):Promise<string>;
+
+export function UpdateTag(arg1:string):Promise<string>;
+
 export function UpdateTask(arg1:string):Promise<string>;
@@ -2,38 +2,206 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddTagToTask(arg1, arg2) {
+  return window['go']['main']['App']['AddTagToTask'](arg1, arg2);
+}
+
+export function CreateFeed(arg1) {
+  return window['go']['main']['App']['CreateFeed'](arg1);
+}
+
+export function CreateProject(arg1) {
+  return window['go']['main']['App']['CreateProject'](arg1);
+}
+
+export function CreateTag(arg1) {
+  return window['go']['main']['App']['CreateTag'](arg1);
+}
+
 export function CreateTask(arg1) {
   return window['go']['main']['App']['CreateTask'](arg1);
 }
 
+export function DeleteFeed(arg1) {
+  return window['go']['main']['App']['DeleteFeed'](arg1);
+}
+
+export function DeleteProject(arg1) {
+  return window['go']['main']['App']['DeleteProject'](arg1);
+}
+
+export function DeleteTag(arg1) {
+  return window['go']['main']['App']['DeleteTag'](arg1);
+}
+
 export function DeleteTask(arg1) {
   return window['go']['main']['App']['DeleteTask'](arg1);
 }
 
+export function DismissReminder(arg1) {
+  return window['go']['main']['App']['DismissReminder'](arg1);
+}
+
+export function EmptyTrash() {
+  return window['go']['main']['App']['EmptyTrash']();
+}
+
+export function ExportICS(arg1) {
+  return window['go']['main']['App']['ExportICS'](arg1);
+}
+
+export function ExportTasks(arg1, arg2) {
+  return window['go']['main']['App']['ExportTasks'](arg1, arg2);
+}
+
+export function ExportTodoTxt(arg1) {
+  return window['go']['main']['App']['ExportTodoTxt'](arg1);
+}
+
+export function GetChildren(arg1) {
+  return window['go']['main']['App']['GetChildren'](arg1);
+}
+
+export function GetFeeds() {
+  return window['go']['main']['App']['GetFeeds']();
+}
+
 export function GetOverdueTasks() {
   return window['go']['main']['App']['GetOverdueTasks']();
 }
 
+export function GetProject(arg1) {
+  return window['go']['main']['App']['GetProject'](arg1);
+}
+
+export function GetProjects(arg1) {
+  return window['go']['main']['App']['GetProjects'](arg1);
+}
+
+export function GetReminders(arg1) {
+  return window['go']['main']['App']['GetReminders'](arg1);
+}
+
+export function GetSubtree(arg1) {
+  return window['go']['main']['App']['GetSubtree'](arg1);
+}
+
+export function GetTags() {
+  return window['go']['main']['App']['GetTags']();
+}
+
 export function GetTask(arg1) {
   return window['go']['main']['App']['GetTask'](arg1);
 }
 
+export function GetTaskHistory(arg1) {
+  return window['go']['main']['App']['GetTaskHistory'](arg1);
+}
+
 export function GetTasks(arg1) {
   return window['go']['main']['App']['GetTasks'](arg1);
 }
 
+export function GetTasksByDateRange(arg1, arg2) {
+  return window['go']['main']['App']['GetTasksByDateRange'](arg1, arg2);
+}
+
 export function GetTasksByPriority(arg1) {
   return window['go']['main']['App']['GetTasksByPriority'](arg1);
 }
 
+export function GetTasksByProject(arg1) {
+  return window['go']['main']['App']['GetTasksByProject'](arg1);
+}
+
 export function GetTasksByStatus(arg1) {
   return window['go']['main']['App']['GetTasksByStatus'](arg1);
 }
 
+export function GetTasksByTags(arg1) {
+  return window['go']['main']['App']['GetTasksByTags'](arg1);
+}
+
+export function GetTasksDueThisWeek() {
+  return window['go']['main']['App']['GetTasksDueThisWeek']();
+}
+
+export function GetTasksDueToday() {
+  return window['go']['main']['App']['GetTasksDueToday']();
+}
+
+export function History() {
+  return window['go']['main']['App']['History']();
+}
+
+export function ImportICS(arg1) {
+  return window['go']['main']['App']['ImportICS'](arg1);
+}
+
+export function ImportTasks(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ImportTasks'](arg1, arg2, arg3);
+}
+
+export function ImportTodoTxt(arg1) {
+  return window['go']['main']['App']['ImportTodoTxt'](arg1);
+}
+
+export function ListTrash() {
+  return window['go']['main']['App']['ListTrash']();
+}
+
+export function MoveTask(arg1) {
+  return window['go']['main']['App']['MoveTask'](arg1);
+}
+
+export function PatchTask(arg1) {
+  return window['go']['main']['App']['PatchTask'](arg1);
+}
+
+export function Redo() {
+  return window['go']['main']['App']['Redo']();
+}
+
+export function RemoveTagFromTask(arg1, arg2) {
+  return window['go']['main']['App']['RemoveTagFromTask'](arg1, arg2);
+}
+
+export function RestoreTask(arg1) {
+  return window['go']['main']['App']['RestoreTask'](arg1);
+}
+
+export function SearchTasks(arg1) {
+  return window['go']['main']['App']['SearchTasks'](arg1);
+}
+
+export function SetRecurrence(arg1) {
+  return window['go']['main']['App']['SetRecurrence'](arg1);
+}
+
+export function SetReminders(arg1) {
+  return window['go']['main']['App']['SetReminders'](arg1);
+}
+
+export function SnoozeReminder(arg1) {
+  return window['go']['main']['App']['SnoozeReminder'](arg1);
+}
+
 export function ToggleTaskStatus(arg1) {
   return window['go']['main']['App']['ToggleTaskStatus'](arg1);
 }
 
+export function Undo() {
+  return window['go']['main']['App']['Undo']();
+}
+
+export function UpdateProject(arg1) {
+  return window['go']['main']['App']['UpdateProject'](arg1);
+}
+
+export function UpdateTag(arg1) {
+  return window['go']['main']['App']['UpdateTag'](arg1);
+}
+
 export function UpdateTask(arg1) {
   return window['go']['main']['App']['UpdateTask'](arg1);
 }
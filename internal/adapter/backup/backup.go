@@ -0,0 +1,60 @@
+// Package backup writes tasks to CSV and JSON Lines files and reads them back.
+//
+// JSON Lines holds one task per line in the same JSON form as the API, so it
+// keeps every field, after a line per project and tag. CSV has a header row
+// and one column per field that an import restores, for editing tasks in a
+// spreadsheet; it carries the name and color of a task's project and the
+// colors of its tags, so only projects and tags without tasks are lost.
+package backup
+
+import (
+	"fmt"
+	"io"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// Format is a file format for exporting and importing tasks
+type Format string
+
+const (
+	CSV   Format = "csv"
+	JSONL Format = "jsonl"
+)
+
+// Valid reports whether the format is supported
+func (f Format) Valid() bool {
+	return f == CSV || f == JSONL
+}
+
+// ContentType returns the media type of a file in the format
+func (f Format) ContentType() string {
+	if f == CSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/jsonl; charset=utf-8"
+}
+
+// Encode writes a backup in the format
+func (f Format) Encode(w io.Writer, data *models.Backup) error {
+	switch f {
+	case CSV:
+		return EncodeCSV(w, data)
+	case JSONL:
+		return EncodeJSONL(w, data)
+	default:
+		return fmt.Errorf("unknown format %q", string(f))
+	}
+}
+
+// Decode reads a backup in the format
+func (f Format) Decode(r io.Reader) (*models.Backup, error) {
+	switch f {
+	case CSV:
+		return DecodeCSV(r)
+	case JSONL:
+		return DecodeJSONL(r)
+	default:
+		return nil, fmt.Errorf("unknown format %q", string(f))
+	}
+}
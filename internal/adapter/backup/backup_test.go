@@ -0,0 +1,192 @@
+package backup
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// testBackup returns a backup that uses every field both formats carry
+func testBackup(t *testing.T) *models.Backup {
+	t.Helper()
+	created := time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)
+	updated := created.Add(90 * time.Minute)
+	due := time.Date(2026, 3, 5, 17, 0, 0, 123000000, time.UTC)
+	deleted := created.Add(48 * time.Hour)
+	recurrence, err := models.ParseRecurrence("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	project := "8d0f6c52-1c1e-4d57-9a3b-61c35b1f0a10"
+	parent := "8d0f6c52-1c1e-4d57-9a3b-61c35b1f0a01"
+	series := "8d0f6c52-1c1e-4d57-9a3b-61c35b1f0a20"
+
+	return &models.Backup{
+		Projects: []*models.Project{{ID: project, Name: "Home, sweet home", Color: "#3b82f6"}},
+		Tasks: []*models.Task{
+			{
+				ID:          parent,
+				Title:       "Renovate",
+				Description: "Kitchen first,\nthen the \"big\" room",
+				Priority:    models.PriorityHigh,
+				Status:      models.StatusActive,
+				DueDate:     &due,
+				Tags:        []models.Tag{{Name: "home", Color: "#ff0000"}, {Name: "diy"}},
+				ProjectID:   &project,
+				Recurrence:  recurrence,
+				SeriesID:    &series,
+				Occurrence:  2,
+				CreatedAt:   created,
+				UpdatedAt:   updated,
+			},
+			{
+				ID:        "8d0f6c52-1c1e-4d57-9a3b-61c35b1f0a02",
+				Title:     "=HYPERLINK(\"http://example.com\")",
+				Priority:  models.PriorityLow,
+				Status:    models.StatusCompleted,
+				Tags:      []models.Tag{},
+				ParentID:  &parent,
+				ProjectID: &project,
+				CreatedAt: created,
+				UpdatedAt: updated,
+				DeletedAt: &deleted,
+			},
+			{
+				ID:          "8d0f6c52-1c1e-4d57-9a3b-61c35b1f0a03",
+				Title:       "'quoted' title",
+				Description: "-5 degrees @ night",
+				Priority:    models.PriorityMedium,
+				Status:      models.StatusActive,
+				Tags:        []models.Tag{{Name: "+1"}},
+				CreatedAt:   created,
+				UpdatedAt:   created,
+			},
+		},
+	}
+}
+
+// roundTrip encodes a backup in the format and decodes the result
+func roundTrip(t *testing.T, f Format, data *models.Backup) (string, *models.Backup) {
+	t.Helper()
+	var b bytes.Buffer
+	if err := f.Encode(&b, data); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	decoded, err := f.Decode(bytes.NewReader(b.Bytes()))
+	if err != nil {
+		t.Fatalf("Decode: %v\n%s", err, b.String())
+	}
+	return b.String(), decoded
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, f := range []Format{CSV, JSONL} {
+		t.Run(string(f), func(t *testing.T) {
+			data := testBackup(t)
+			if f == JSONL {
+				data.Tags = []*models.Tag{{ID: "t1", Name: "unused", Color: "#00ff00"}}
+			}
+
+			out, decoded := roundTrip(t, f, data)
+			if len(decoded.Tasks) != len(data.Tasks) {
+				t.Fatalf("decoded %d tasks, want %d:\n%s", len(decoded.Tasks), len(data.Tasks), out)
+			}
+			for i, got := range decoded.Tasks {
+				if !reflect.DeepEqual(got, data.Tasks[i]) {
+					t.Errorf("task %d = %+v, want %+v", i, got, data.Tasks[i])
+				}
+			}
+			if !reflect.DeepEqual(decoded.Projects, data.Projects) {
+				t.Errorf("projects = %+v, want %+v", decoded.Projects, data.Projects)
+			}
+			if !reflect.DeepEqual(decoded.Tags, data.Tags) {
+				t.Errorf("tags = %+v, want %+v", decoded.Tags, data.Tags)
+			}
+		})
+	}
+}
+
+func TestCSVEscapesFormulas(t *testing.T) {
+	out, _ := roundTrip(t, CSV, testBackup(t))
+	for _, want := range []string{`"'=HYPERLINK(""http://example.com"")"`, "''quoted' title", "'-5 degrees @ night", "'+1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output has no %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormulaEscape(t *testing.T) {
+	tests := []struct {
+		cell    string
+		escaped string
+	}{
+		{"", ""},
+		{"plain", "plain"},
+		{"a=b", "a=b"},
+		{"=1+1", "'=1+1"},
+		{"+49 30 1234", "'+49 30 1234"},
+		{"-x", "'-x"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\tindented", "'\tindented"},
+		{"\rreturn", "'\rreturn"},
+		{"'apostrophe", "''apostrophe"},
+		{"'=already escaped", "''=already escaped"},
+		{"''", "'''"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cell, func(t *testing.T) {
+			escaped := escapeFormula(tt.cell)
+			if escaped != tt.escaped {
+				t.Errorf("escapeFormula(%q) = %q, want %q", tt.cell, escaped, tt.escaped)
+			}
+			if got := unescapeFormula(escaped); got != tt.cell {
+				t.Errorf("unescapeFormula(%q) = %q, want %q", escaped, got, tt.cell)
+			}
+		})
+	}
+}
+
+func TestUnescapeHandWrittenCells(t *testing.T) {
+	// A spreadsheet user may type a leading apostrophe themselves
+	for cell, want := range map[string]string{
+		"'hello": "'hello",
+		"'":      "'",
+		"'=1+1":  "=1+1",
+		"=1+1":   "=1+1",
+	} {
+		if got := unescapeFormula(cell); got != want {
+			t.Errorf("unescapeFormula(%q) = %q, want %q", cell, got, want)
+		}
+	}
+}
+
+func TestDecodeCSVColumns(t *testing.T) {
+	in := "\ufeffTitle,PRIORITY,tags,unknown,project\n" +
+		"Buy milk,2, errands ; home ,x,Chores\n"
+	data, err := DecodeCSV(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("DecodeCSV: %v", err)
+	}
+	if len(data.Tasks) != 1 {
+		t.Fatalf("decoded %d tasks, want 1", len(data.Tasks))
+	}
+	task := data.Tasks[0]
+	if task.Title != "Buy milk" || task.Priority != models.PriorityHigh {
+		t.Errorf("task = %+v, want Buy milk with high priority", task)
+	}
+	if len(task.Tags) != 2 || task.Tags[0].Name != "errands" || task.Tags[1].Name != "home" {
+		t.Errorf("tags = %+v, want errands and home", task.Tags)
+	}
+	if len(data.Projects) != 1 || data.Projects[0].Name != "Chores" || task.ProjectID == nil || *task.ProjectID != data.Projects[0].ID {
+		t.Errorf("project = %+v of %v, want Chores referred to by the task", data.Projects, task.ProjectID)
+	}
+
+	if _, err := DecodeCSV(strings.NewReader("name,priority\nBuy milk,2\n")); err == nil {
+		t.Error("DecodeCSV without a title column succeeded")
+	}
+}
@@ -0,0 +1,313 @@
+package backup
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// csvColumns is the header of an exported CSV file. Priority and status are
+// the numbers the API uses, tags and their colors are separated by
+// tagSeparator and times are RFC 3339. The project and projectColor columns
+// describe the project of the task, so that an import can create it. A cell
+// that a spreadsheet would run as a formula is written with formulaEscape
+// before it, which an import removes again.
+var csvColumns = []string{
+	"id", "title", "description", "priority", "status", "dueDate", "tags",
+	"projectId", "parentId", "recurrence", "createdAt", "updatedAt",
+	"seriesId", "occurrence", "deletedAt", "project", "projectColor", "tagColors",
+}
+
+// formulaPrefixes start the cells that spreadsheets evaluate as formulas
+const formulaPrefixes = "=+-@\t\r"
+
+// formulaEscape is put before a cell that would otherwise be a formula
+const formulaEscape = "'"
+
+// tagSeparator separates the tag names in the tags column and their colors in the tagColors column
+const tagSeparator = ";"
+
+// EncodeCSV writes a header row and one row per task
+func EncodeCSV(w io.Writer, data *models.Backup) error {
+	projects := make(map[string]*models.Project, len(data.Projects))
+	for _, project := range data.Projects {
+		projects[project.ID] = project
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return err
+	}
+
+	for _, task := range data.Tasks {
+		names := make([]string, len(task.Tags))
+		colors := make([]string, len(task.Tags))
+		colored := false
+		for i, tag := range task.Tags {
+			names[i] = tag.Name
+			colors[i] = tag.Color
+			colored = colored || tag.Color != ""
+		}
+		if !colored {
+			colors = nil
+		}
+
+		recurrence := ""
+		if task.Recurrence != nil {
+			recurrence = task.Recurrence.String()
+		}
+		occurrence := ""
+		if task.Occurrence > 0 {
+			occurrence = strconv.Itoa(task.Occurrence)
+		}
+		var projectName, projectColor string
+		if task.ProjectID != nil {
+			if project, ok := projects[*task.ProjectID]; ok {
+				projectName, projectColor = project.Name, project.Color
+			}
+		}
+
+		row := []string{
+			task.ID,
+			task.Title,
+			task.Description,
+			strconv.Itoa(int(task.Priority)),
+			strconv.Itoa(int(task.Status)),
+			formatTime(task.DueDate),
+			strings.Join(names, tagSeparator),
+			deref(task.ProjectID),
+			deref(task.ParentID),
+			recurrence,
+			formatTime(&task.CreatedAt),
+			formatTime(&task.UpdatedAt),
+			deref(task.SeriesID),
+			occurrence,
+			formatTime(task.DeletedAt),
+			projectName,
+			projectColor,
+			strings.Join(colors, tagSeparator),
+		}
+		for i, cell := range row {
+			row[i] = escapeFormula(cell)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// DecodeCSV reads the rows of a CSV file with a header row. Columns are
+// matched by name, ignoring case; unknown columns are skipped and missing
+// ones leave their field empty, but a title column is required. A project
+// named in the project column is part of the backup, under its projectId or,
+// without one, under its name.
+func DecodeCSV(r io.Reader) (*models.Backup, error) {
+	data := &models.Backup{}
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return data, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("no title column")
+	}
+
+	projects := make(map[string]bool)
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return data, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		task, project, err := decodeRow(row, columns)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if project != nil && !projects[project.ID] {
+			projects[project.ID] = true
+			data.Projects = append(data.Projects, project)
+		}
+		data.Tasks = append(data.Tasks, task)
+	}
+}
+
+// decodeRow reads a task and the project it names from a row; columns maps
+// lower-case column names to their index
+func decodeRow(row []string, columns map[string]int) (*models.Task, *models.Project, error) {
+	// Text is taken as it is; the other values are trimmed
+	text := func(name string) string {
+		if i, ok := columns[strings.ToLower(name)]; ok {
+			return unescapeFormula(row[i])
+		}
+		return ""
+	}
+	get := func(name string) string {
+		return strings.TrimSpace(text(name))
+	}
+
+	task := &models.Task{
+		ID:          get("id"),
+		Title:       text("title"),
+		Description: text("description"),
+		Tags:        []models.Tag{},
+		ProjectID:   ref(get("projectId")),
+		ParentID:    ref(get("parentId")),
+		SeriesID:    ref(get("seriesId")),
+	}
+
+	var project *models.Project
+	if name := get("project"); name != "" {
+		id := get("projectId")
+		if id == "" {
+			id = name
+			task.ProjectID = ref(id)
+		}
+		project = &models.Project{ID: id, Name: name, Color: get("projectColor")}
+	}
+
+	colors := strings.Split(get("tagColors"), tagSeparator)
+	for i, name := range strings.Split(get("tags"), tagSeparator) {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		tag := models.Tag{Name: name}
+		if i < len(colors) {
+			tag.Color = strings.TrimSpace(colors[i])
+		}
+		task.Tags = append(task.Tags, tag)
+	}
+
+	if value := get("priority"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid priority %q", value)
+		}
+		task.Priority = models.Priority(n)
+	}
+	if value := get("status"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid status %q", value)
+		}
+		task.Status = models.Status(n)
+	}
+	if value := get("occurrence"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid occurrence %q", value)
+		}
+		task.Occurrence = n
+	}
+	if value := get("recurrence"); value != "" {
+		recurrence, err := models.ParseRecurrence(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid recurrence %q: %w", value, err)
+		}
+		task.Recurrence = recurrence
+	}
+
+	var err error
+	if task.DueDate, err = parseTime("dueDate", get("dueDate")); err != nil {
+		return nil, nil, err
+	}
+	created, err := parseTime("createdAt", get("createdAt"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if created != nil {
+		task.CreatedAt = *created
+	}
+	updated, err := parseTime("updatedAt", get("updatedAt"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if updated != nil {
+		task.UpdatedAt = *updated
+	}
+	if task.DeletedAt, err = parseTime("deletedAt", get("deletedAt")); err != nil {
+		return nil, nil, err
+	}
+	return task, project, nil
+}
+
+// escapeFormula keeps a spreadsheet from running a cell as a formula by
+// putting formulaEscape before it. A cell that already starts with the escape
+// gets another one, so that unescapeFormula can tell the two apart.
+func escapeFormula(cell string) string {
+	if isFormula(cell) || strings.HasPrefix(cell, formulaEscape) {
+		return formulaEscape + cell
+	}
+	return cell
+}
+
+// unescapeFormula removes the formulaEscape that escapeFormula added; an
+// escape before any other character is part of the text
+func unescapeFormula(cell string) string {
+	rest := strings.TrimPrefix(cell, formulaEscape)
+	if rest != cell && (isFormula(rest) || strings.HasPrefix(rest, formulaEscape)) {
+		return rest
+	}
+	return cell
+}
+
+// isFormula reports whether a spreadsheet would take the cell for a formula
+func isFormula(cell string) bool {
+	return cell != "" && strings.ContainsRune(formulaPrefixes, rune(cell[0]))
+}
+
+// formatTime writes an RFC 3339 time; nil is empty
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// parseTime reads an RFC 3339 time; empty is nil
+func parseTime(column, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", column, value)
+	}
+	return &t, nil
+}
+
+// deref returns the value of an optional ID, or "" when it is unset
+func deref(id *string) string {
+	if id == nil {
+		return ""
+	}
+	return *id
+}
+
+// ref makes an optional ID, unset when empty
+func ref(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
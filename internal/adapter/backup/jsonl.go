@@ -0,0 +1,78 @@
+package backup
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// record is a JSON Lines line that holds a project or a tag; any other line
+// is a task, which keeps files from before projects and tags were exported readable
+type record struct {
+	Project *models.Project `json:"project,omitempty"`
+	Tag     *models.Tag     `json:"tag,omitempty"`
+}
+
+// EncodeJSONL writes one JSON project, tag or task per line, tasks last
+func EncodeJSONL(w io.Writer, data *models.Backup) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	enc.SetEscapeHTML(false)
+	for _, project := range data.Projects {
+		if err := enc.Encode(record{Project: project}); err != nil {
+			return err
+		}
+	}
+	for _, tag := range data.Tags {
+		if err := enc.Encode(record{Tag: tag}); err != nil {
+			return err
+		}
+	}
+	for _, task := range data.Tasks {
+		if err := enc.Encode(task); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// DecodeJSONL reads one JSON project, tag or task per non-blank line
+func DecodeJSONL(r io.Reader) (*models.Backup, error) {
+	data := &models.Backup{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<24)
+
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if text == "" {
+			continue
+		}
+
+		var rec record
+		if err := json.Unmarshal([]byte(text), &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+		switch {
+		case rec.Project != nil:
+			data.Projects = append(data.Projects, rec.Project)
+		case rec.Tag != nil:
+			data.Tags = append(data.Tags, rec.Tag)
+		default:
+			task := &models.Task{}
+			if err := json.Unmarshal([]byte(text), task); err != nil {
+				return nil, fmt.Errorf("line %d: %w", number, err)
+			}
+			data.Tasks = append(data.Tasks, task)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/ports"
+
+	"github.com/lib/pq"
+)
+
+// postgresUniqueViolation is the SQLSTATE of a unique constraint violation
+const postgresUniqueViolation = "23505"
+
+// affected returns domain.NotFound(entity) when a statement matched no rows
+func affected(result sql.Result, err error, entity string) error {
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.NotFound(entity)
+	}
+
+	return nil
+}
+
+// uniqueViolation turns a unique constraint violation into a domain conflict
+func uniqueViolation(err error, message string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == postgresUniqueViolation {
+		return domain.Conflict(message)
+	}
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return domain.Conflict(message)
+	}
+	return err
+}
+
+// versionConflict explains why a versioned task update matched no row: the
+// task is either gone or was changed by someone else since it was read
+func versionConflict(ctx context.Context, repo ports.TaskRepository, id string) error {
+	current, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return domain.StaleVersion("task", current)
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// taskEventColumns is the column list read by scanTaskEvent
+const taskEventColumns = "id, task_id, action, actor, via, changes, created_at"
+
+// appendTaskEvents inserts events in one transaction and reads back their IDs.
+// Both SQL repositories use it; SQLite supports RETURNING as well.
+func appendTaskEvents(ctx context.Context, db *sql.DB, dialect sqlDialect, events []*models.TaskEvent) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, event := range events {
+		changes, err := json.Marshal(event.Changes)
+		if err != nil {
+			return fmt.Errorf("task event: %w", err)
+		}
+
+		b := &whereBuilder{dialect: dialect}
+		query := fmt.Sprintf(
+			"INSERT INTO task_events (task_id, action, actor, via, changes, created_at) VALUES (%s, %s, %s, %s, %s, %s) RETURNING id",
+			b.arg(event.TaskID), b.arg(string(event.Action)), b.arg(event.Actor), b.arg(event.Via), b.arg(string(changes)), b.arg(event.At))
+		if err := tx.QueryRowContext(ctx, query, b.args...).Scan(&event.ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// getTaskEvents retrieves the events of a task, oldest first
+func getTaskEvents(ctx context.Context, db *sql.DB, dialect sqlDialect, taskID string) ([]*models.TaskEvent, error) {
+	b := &whereBuilder{dialect: dialect}
+	b.add("task_id = " + b.arg(taskID))
+	query := "SELECT " + taskEventColumns + " FROM task_events" + b.where() + " ORDER BY id"
+
+	rows, err := db.QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*models.TaskEvent
+	for rows.Next() {
+		event, err := scanTaskEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// scanTaskEvent reads an event selected with taskEventColumns
+func scanTaskEvent(row rowScanner) (*models.TaskEvent, error) {
+	event := &models.TaskEvent{}
+	var changes string
+	if err := row.Scan(&event.ID, &event.TaskID, &event.Action, &event.Actor, &event.Via, &changes, &event.At); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(changes), &event.Changes); err != nil {
+		return nil, fmt.Errorf("task event %d: %w", event.ID, err)
+	}
+	return event, nil
+}
+
+// AppendTaskEvents stores audit events and sets their IDs
+func (r *PostgresRepository) AppendTaskEvents(ctx context.Context, events []*models.TaskEvent) error {
+	return appendTaskEvents(ctx, r.db, postgresDialect, events)
+}
+
+// GetTaskEvents retrieves the audit events of a task, oldest first
+func (r *PostgresRepository) GetTaskEvents(ctx context.Context, taskID string) ([]*models.TaskEvent, error) {
+	return getTaskEvents(ctx, r.db, postgresDialect, taskID)
+}
+
+// AppendTaskEvents stores audit events and sets their IDs
+func (r *SQLiteRepository) AppendTaskEvents(ctx context.Context, events []*models.TaskEvent) error {
+	return appendTaskEvents(ctx, r.db, sqliteDialect, events)
+}
+
+// GetTaskEvents retrieves the audit events of a task, oldest first
+func (r *SQLiteRepository) GetTaskEvents(ctx context.Context, taskID string) ([]*models.TaskEvent, error) {
+	return getTaskEvents(ctx, r.db, sqliteDialect, taskID)
+}
+
+// AppendTaskEvents stores audit events and sets their IDs. The changes are
+// stored as JSON, like in the SQL repositories, so that values read back
+// have the same types everywhere.
+func (r *MemoryRepository) AppendTaskEvents(ctx context.Context, events []*models.TaskEvent) error {
+	stored := make([]*models.TaskEvent, len(events))
+	for i, event := range events {
+		data, err := json.Marshal(event.Changes)
+		if err != nil {
+			return fmt.Errorf("task event: %w", err)
+		}
+		eventCopy := *event
+		eventCopy.Changes = nil
+		if err := json.Unmarshal(data, &eventCopy.Changes); err != nil {
+			return fmt.Errorf("task event: %w", err)
+		}
+		stored[i] = &eventCopy
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, event := range stored {
+		r.lastEventID++
+		event.ID = r.lastEventID
+		events[i].ID = event.ID
+		r.events = append(r.events, event)
+	}
+	return nil
+}
+
+// GetTaskEvents retrieves the audit events of a task, oldest first
+func (r *MemoryRepository) GetTaskEvents(ctx context.Context, taskID string) ([]*models.TaskEvent, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var events []*models.TaskEvent
+	for _, event := range r.events {
+		if event.TaskID == taskID {
+			eventCopy := *event
+			events = append(events, &eventCopy)
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,193 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+)
+
+// feedColumns is the column list read by scanFeed
+const feedColumns = "id, name, token, filter, created_at"
+
+// scanFeed reads a feed selected with feedColumns
+func scanFeed(row rowScanner) (*models.Feed, error) {
+	feed := &models.Feed{}
+	var filter string
+	if err := row.Scan(&feed.ID, &feed.Name, &feed.Token, &filter, &feed.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(filter), &feed.Filter); err != nil {
+		return nil, fmt.Errorf("feed %s: %w", feed.ID, err)
+	}
+	return feed, nil
+}
+
+// createFeed inserts a feed with its filter as JSON
+func createFeed(ctx context.Context, db *sql.DB, dialect sqlDialect, feed *models.Feed) error {
+	filter, err := json.Marshal(feed.Filter)
+	if err != nil {
+		return fmt.Errorf("feed filter: %w", err)
+	}
+
+	b := &whereBuilder{dialect: dialect}
+	query := fmt.Sprintf("INSERT INTO feeds (%s) VALUES (%s, %s, %s, %s, %s)", feedColumns,
+		b.arg(feed.ID), b.arg(feed.Name), b.arg(feed.Token), b.arg(string(filter)), b.arg(feed.CreatedAt))
+	_, err = db.ExecContext(ctx, query, b.args...)
+	return uniqueViolation(err, "feed token already exists")
+}
+
+// getFeedByToken retrieves the feed with the given token
+func getFeedByToken(ctx context.Context, db *sql.DB, dialect sqlDialect, token string) (*models.Feed, error) {
+	b := &whereBuilder{dialect: dialect}
+	b.add("token = " + b.arg(token))
+	query := "SELECT " + feedColumns + " FROM feeds" + b.where()
+
+	feed, err := scanFeed(db.QueryRowContext(ctx, query, b.args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NotFound("feed")
+		}
+		return nil, err
+	}
+	return feed, nil
+}
+
+// getFeeds retrieves every feed, oldest first
+func getFeeds(ctx context.Context, db *sql.DB) ([]*models.Feed, error) {
+	rows, err := db.QueryContext(ctx, "SELECT "+feedColumns+" FROM feeds ORDER BY created_at, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	feeds := []*models.Feed{}
+	for rows.Next() {
+		feed, err := scanFeed(rows)
+		if err != nil {
+			return nil, err
+		}
+		feeds = append(feeds, feed)
+	}
+
+	return feeds, rows.Err()
+}
+
+// deleteFeed deletes a feed by ID
+func deleteFeed(ctx context.Context, db *sql.DB, dialect sqlDialect, id string) error {
+	b := &whereBuilder{dialect: dialect}
+	b.add("id = " + b.arg(id))
+	result, err := db.ExecContext(ctx, "DELETE FROM feeds"+b.where(), b.args...)
+	return affected(result, err, "feed")
+}
+
+// CreateFeed creates a new feed
+func (r *PostgresRepository) CreateFeed(ctx context.Context, feed *models.Feed) error {
+	return createFeed(ctx, r.db, postgresDialect, feed)
+}
+
+// GetFeedByToken retrieves the feed with the given token
+func (r *PostgresRepository) GetFeedByToken(ctx context.Context, token string) (*models.Feed, error) {
+	return getFeedByToken(ctx, r.db, postgresDialect, token)
+}
+
+// GetFeeds retrieves every feed, oldest first
+func (r *PostgresRepository) GetFeeds(ctx context.Context) ([]*models.Feed, error) {
+	return getFeeds(ctx, r.db)
+}
+
+// DeleteFeed deletes a feed by ID
+func (r *PostgresRepository) DeleteFeed(ctx context.Context, id string) error {
+	return deleteFeed(ctx, r.db, postgresDialect, id)
+}
+
+// CreateFeed creates a new feed
+func (r *SQLiteRepository) CreateFeed(ctx context.Context, feed *models.Feed) error {
+	return createFeed(ctx, r.db, sqliteDialect, feed)
+}
+
+// GetFeedByToken retrieves the feed with the given token
+func (r *SQLiteRepository) GetFeedByToken(ctx context.Context, token string) (*models.Feed, error) {
+	return getFeedByToken(ctx, r.db, sqliteDialect, token)
+}
+
+// GetFeeds retrieves every feed, oldest first
+func (r *SQLiteRepository) GetFeeds(ctx context.Context) ([]*models.Feed, error) {
+	return getFeeds(ctx, r.db)
+}
+
+// DeleteFeed deletes a feed by ID
+func (r *SQLiteRepository) DeleteFeed(ctx context.Context, id string) error {
+	return deleteFeed(ctx, r.db, sqliteDialect, id)
+}
+
+// CreateFeed creates a new feed
+func (r *MemoryRepository) CreateFeed(ctx context.Context, feed *models.Feed) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.feeds {
+		if existing.Token == feed.Token {
+			return domain.Conflict("feed token already exists")
+		}
+	}
+	r.feeds[feed.ID] = copyFeed(feed)
+	return nil
+}
+
+// GetFeedByToken retrieves the feed with the given token
+func (r *MemoryRepository) GetFeedByToken(ctx context.Context, token string) (*models.Feed, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, feed := range r.feeds {
+		if feed.Token == token {
+			return copyFeed(feed), nil
+		}
+	}
+	return nil, domain.NotFound("feed")
+}
+
+// GetFeeds retrieves every feed, oldest first
+func (r *MemoryRepository) GetFeeds(ctx context.Context) ([]*models.Feed, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	feeds := make([]*models.Feed, 0, len(r.feeds))
+	for _, feed := range r.feeds {
+		feeds = append(feeds, copyFeed(feed))
+	}
+	sort.Slice(feeds, func(i, j int) bool {
+		if !feeds[i].CreatedAt.Equal(feeds[j].CreatedAt) {
+			return feeds[i].CreatedAt.Before(feeds[j].CreatedAt)
+		}
+		return feeds[i].ID < feeds[j].ID
+	})
+	return feeds, nil
+}
+
+// DeleteFeed deletes a feed by ID
+func (r *MemoryRepository) DeleteFeed(ctx context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.feeds[id]; !exists {
+		return domain.NotFound("feed")
+	}
+	delete(r.feeds, id)
+	return nil
+}
+
+// copyFeed copies a feed through JSON, like the SQL repositories store it,
+// so that callers never share its filter
+func copyFeed(feed *models.Feed) *models.Feed {
+	feedCopy := *feed
+	data, _ := json.Marshal(feed.Filter)
+	feedCopy.Filter = models.FilterOptions{}
+	json.Unmarshal(data, &feedCopy.Filter)
+	return &feedCopy
+}
@@ -0,0 +1,248 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/query"
+)
+
+// sqlDialect describes the differences between the SQL repositories that matter for query building
+type sqlDialect struct {
+	placeholder func(n int) string
+	value       func(v interface{}) interface{} // optional argument conversion
+
+	// Full-text search: searchQuery turns query terms into the engine's query
+	// syntax and searchPhrase does the same for adjacent words; searchMatch
+	// and searchRank take its placeholder
+	searchQuery  func(terms []string) string
+	searchPhrase func(words []string) string
+	searchMatch  string
+	searchRank   string
+
+	binaryCollation string // COLLATE clause making text compare byte-wise
+}
+
+// postgresDialect uses numbered $n placeholders and the tasks.search tsvector
+var postgresDialect = sqlDialect{
+	placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+	searchQuery: func(terms []string) string {
+		return strings.Join(terms, ":* & ") + ":*"
+	},
+	searchPhrase: func(words []string) string {
+		return strings.Join(words, ":* <-> ") + ":*"
+	},
+	searchMatch: "search @@ to_tsquery('simple', %s)",
+	searchRank:  "ts_rank(search, to_tsquery('simple', %s))",
+	// The database collation may follow locale rules
+	binaryCollation: ` COLLATE "C"`,
+}
+
+// sqliteDialect uses positional ? placeholders, UTC timestamps and the tasks_fts FTS5 table
+var sqliteDialect = sqlDialect{
+	placeholder: func(int) string { return "?" },
+	value:       sqliteValue,
+	searchQuery: func(terms []string) string {
+		return `"` + strings.Join(terms, `"* "`) + `"*`
+	},
+	searchPhrase: func(words []string) string {
+		return `"` + strings.Join(words, `"* + "`) + `"*`
+	},
+	searchMatch: "rowid IN (SELECT rowid FROM tasks_fts WHERE tasks_fts MATCH %s)",
+	searchRank:  "(SELECT -bm25(tasks_fts, 1.0, 0.4) FROM tasks_fts WHERE tasks_fts MATCH %s AND tasks_fts.rowid = tasks.rowid)",
+}
+
+// whereBuilder collects WHERE conditions and their arguments for one dialect
+type whereBuilder struct {
+	clauses []string
+	args    []interface{}
+	dialect sqlDialect
+}
+
+// arg registers an argument and returns its placeholder
+func (b *whereBuilder) arg(value interface{}) string {
+	if b.dialect.value != nil {
+		value = b.dialect.value(value)
+	}
+	b.args = append(b.args, value)
+	return b.dialect.placeholder(len(b.args))
+}
+
+// list registers a non-empty list of arguments and returns "(p1, p2, ...)"
+func (b *whereBuilder) list(values []string) string {
+	placeholders := make([]string, len(values))
+	for i, value := range values {
+		placeholders[i] = b.arg(value)
+	}
+	return "(" + strings.Join(placeholders, ", ") + ")"
+}
+
+// add appends a condition
+func (b *whereBuilder) add(clause string) {
+	b.clauses = append(b.clauses, clause)
+}
+
+// where returns the WHERE clause, or "" when there are no conditions
+func (b *whereBuilder) where() string {
+	if len(b.clauses) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.clauses, " AND ")
+}
+
+// buildTaskFilter translates FilterOptions into conditions on the tasks table.
+// Both SQL repositories use it so that they select exactly the same tasks.
+func buildTaskFilter(filter *models.FilterOptions, dialect sqlDialect) (*whereBuilder, error) {
+	b := &whereBuilder{dialect: dialect}
+	if err := b.taskFilter(filter); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// buildTaskSearch returns a query selecting taskColumns plus a relevance rank
+// for the tasks matching filter, which must have a non-empty Query
+func buildTaskSearch(filter *models.FilterOptions, dialect sqlDialect) (string, []interface{}, error) {
+	b := &whereBuilder{dialect: dialect}
+	// The rank argument comes first because ? placeholders are positional
+	rank := fmt.Sprintf(dialect.searchRank, b.arg(dialect.searchQuery(models.Tokenize(filter.Query))))
+	if err := b.taskFilter(filter); err != nil {
+		return "", nil, err
+	}
+
+	query := "SELECT " + taskColumns + ", " + rank + " AS rank FROM tasks" + b.where() +
+		" ORDER BY rank DESC, created_at DESC, id ASC"
+	return query, b.args, nil
+}
+
+// taskFilter adds the conditions of FilterOptions; it fails only on an
+// invalid Expression. Trashed tasks are left out unless filter asks for them.
+func (b *whereBuilder) taskFilter(filter *models.FilterOptions) error {
+	if filter == nil || !filter.Trashed {
+		b.add("deleted_at IS NULL")
+	} else {
+		b.add("deleted_at IS NOT NULL")
+	}
+	if filter == nil {
+		return nil
+	}
+
+	expr, err := query.Parse(filter.Expression)
+	if err != nil {
+		return err
+	}
+	if expr != nil {
+		b.add(b.expression(expr))
+	}
+
+	if terms := models.Tokenize(filter.Query); len(terms) > 0 {
+		b.add(fmt.Sprintf(b.dialect.searchMatch, b.arg(b.dialect.searchQuery(terms))))
+	}
+
+	if filter.Status != nil {
+		b.add("status = " + b.arg(*filter.Status))
+	}
+
+	if filter.Priority != nil {
+		b.add("priority = " + b.arg(*filter.Priority))
+	}
+
+	b.timeRange("due_date", filter.DueFrom, filter.DueTo)
+	b.timeRange("created_at", filter.CreatedFrom, filter.CreatedTo)
+	b.timeRange("updated_at", filter.UpdatedFrom, filter.UpdatedTo)
+
+	// The deprecated range of old clients includes its end
+	if filter.DateFrom != nil {
+		b.add("created_at >= " + b.arg(*filter.DateFrom))
+	}
+	if filter.DateTo != nil {
+		b.add("created_at <= " + b.arg(*filter.DateTo))
+	}
+
+	if filter.HasDueDate != nil {
+		if *filter.HasDueDate {
+			b.add("due_date IS NOT NULL")
+		} else {
+			b.add("due_date IS NULL")
+		}
+	}
+
+	if filter.ParentID != nil {
+		b.add("parent_id = " + b.arg(*filter.ParentID))
+	}
+
+	if filter.RootOnly {
+		b.add("parent_id IS NULL")
+	}
+
+	if filter.SeriesID != nil {
+		b.add("series_id = " + b.arg(*filter.SeriesID))
+	}
+
+	if filter.ProjectID != nil {
+		if *filter.ProjectID == "" {
+			b.add("project_id IS NULL")
+		} else {
+			b.add("project_id = " + b.arg(*filter.ProjectID))
+		}
+	}
+
+	if len(filter.AnyTags) > 0 {
+		b.add("EXISTS (SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id AND LOWER(tg.name) IN " +
+			b.list(lowerNames(filter.AnyTags)) + ")")
+	}
+
+	if len(filter.AllTags) > 0 {
+		names := lowerNames(filter.AllTags)
+		b.add(fmt.Sprintf(
+			"(SELECT COUNT(*) FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id AND LOWER(tg.name) IN %s) = %d",
+			b.list(names), len(names)))
+	}
+
+	if filter.Tags != nil {
+		names := lowerNames(filter.Tags)
+		if len(names) > 0 {
+			b.add(fmt.Sprintf(
+				"(SELECT COUNT(*) FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id AND LOWER(tg.name) IN %s) = %d",
+				b.list(names), len(names)))
+		}
+		b.add(fmt.Sprintf("(SELECT COUNT(*) FROM task_tags tt WHERE tt.task_id = tasks.id) = %d", len(names)))
+	}
+
+	return nil
+}
+
+// timeRange adds the bounds of a [from, to) range on a column; either may be nil.
+// Rows with a NULL column never match a bound.
+func (b *whereBuilder) timeRange(column string, from, to *time.Time) {
+	if from != nil {
+		b.add(column + " >= " + b.arg(*from))
+	}
+	if to != nil {
+		b.add(column + " < " + b.arg(*to))
+	}
+}
+
+// taskLimit builds the LIMIT clause of a page, or "" for all tasks
+func taskLimit(filter *models.FilterOptions) string {
+	if filter == nil || filter.Limit <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" LIMIT %d", filter.Limit)
+}
+
+// lowerNames lowercases and de-duplicates tag names for case-insensitive matching
+func lowerNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	return result
+}
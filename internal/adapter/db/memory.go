@@ -4,22 +4,41 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
+	"todo-wails-go/internal/domain"
 	"todo-wails-go/internal/domain/models"
 	"todo-wails-go/internal/domain/ports"
+	"todo-wails-go/internal/domain/query"
 )
 
 // MemoryRepository implements TaskRepository interface using in-memory storage
 type MemoryRepository struct {
-	tasks map[string]*models.Task
-	mutex sync.RWMutex
+	tasks     map[string]*models.Task
+	tags      map[string]*models.Tag
+	taskTags  map[string][]string // task ID -> tag IDs
+	projects  map[string]*models.Project
+	index     *searchIndex
+	events    []*models.TaskEvent // append-only, in ID order
+	reminders map[string]*models.Reminder
+	feeds     map[string]*models.Feed
+
+	lastEventID int64
+	mutex       sync.RWMutex
 }
 
 // NewMemoryRepository creates a new in-memory repository
 func NewMemoryRepository() ports.TaskRepository {
 	return &MemoryRepository{
-		tasks: make(map[string]*models.Task),
+		tasks:     make(map[string]*models.Task),
+		tags:      make(map[string]*models.Tag),
+		taskTags:  make(map[string][]string),
+		projects:  make(map[string]*models.Project),
+		index:     newSearchIndex(),
+		reminders: make(map[string]*models.Reminder),
+		feeds:     make(map[string]*models.Feed),
 	}
 }
 
@@ -28,7 +47,10 @@ func (r *MemoryRepository) Create(ctx context.Context, task *models.Task) error
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	r.tasks[task.ID] = task
+	stored := *task
+	r.tasks[task.ID] = &stored
+	r.setTaskTags(task)
+	r.index.add(&stored)
 	return nil
 }
 
@@ -39,11 +61,12 @@ func (r *MemoryRepository) GetByID(ctx context.Context, id string) (*models.Task
 
 	task, exists := r.tasks[id]
 	if !exists {
-		return nil, fmt.Errorf("task not found")
+		return nil, domain.NotFound("task")
 	}
 
 	// Return a copy to avoid race conditions
 	taskCopy := *task
+	taskCopy.Tags = r.taskTagList(id)
 	return &taskCopy, nil
 }
 
@@ -52,75 +75,173 @@ func (r *MemoryRepository) GetAll(ctx context.Context, filter *models.FilterOpti
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
+	tasks, err := r.filterTasks(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, cursor, err := taskPage(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return compareTasks(tasks[i], tasks[j], keys) < 0
+	})
+
+	if cursor != nil {
+		after := cursor.Task()
+		start := sort.Search(len(tasks), func(i int) bool {
+			return compareTasks(tasks[i], after, keys) > 0
+		})
+		tasks = tasks[start:]
+	}
+
+	if filter != nil && filter.Limit > 0 && len(tasks) > filter.Limit {
+		tasks = tasks[:filter.Limit]
+	}
+
+	return tasks, nil
+}
+
+// Count returns the number of tasks matching filter, ignoring its page
+func (r *MemoryRepository) Count(ctx context.Context, filter *models.FilterOptions) (int, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	tasks, err := r.filterTasks(filter)
+	if err != nil {
+		return 0, err
+	}
+	return len(tasks), nil
+}
+
+// filterTasks returns copies of the tasks matching filter, unsorted; the caller holds the lock
+func (r *MemoryRepository) filterTasks(filter *models.FilterOptions) ([]*models.Task, error) {
+	var scores map[string]float64
+	var expr query.Expr
+	if filter != nil {
+		var err error
+		if expr, err = query.Parse(filter.Expression); err != nil {
+			return nil, err
+		}
+		if terms := models.Tokenize(filter.Query); len(terms) > 0 {
+			scores = r.index.scores(terms)
+		}
+	}
+
+	trashed := filter != nil && filter.Trashed
 	var tasks []*models.Task
 
 	for _, task := range r.tasks {
+		if (task.DeletedAt != nil) != trashed {
+			continue
+		}
+
 		// Apply filters
 		if filter != nil {
+			if scores != nil {
+				if _, ok := scores[task.ID]; !ok {
+					continue
+				}
+			}
 			if filter.Status != nil && task.Status != *filter.Status {
 				continue
 			}
 			if filter.Priority != nil && task.Priority != *filter.Priority {
 				continue
 			}
-			if filter.DateFrom != nil && task.CreatedAt.Before(*filter.DateFrom) {
+			if !inTimeRange(task.DueDate, filter.DueFrom, filter.DueTo) ||
+				!inTimeRange(&task.CreatedAt, filter.CreatedFrom, filter.CreatedTo) ||
+				!inTimeRange(&task.UpdatedAt, filter.UpdatedFrom, filter.UpdatedTo) {
+				continue
+			}
+			if (filter.DateFrom != nil && task.CreatedAt.Before(*filter.DateFrom)) ||
+				(filter.DateTo != nil && task.CreatedAt.After(*filter.DateTo)) {
 				continue
 			}
-			if filter.DateTo != nil && task.CreatedAt.After(*filter.DateTo) {
+			if filter.HasDueDate != nil && (task.DueDate != nil) != *filter.HasDueDate {
+				continue
+			}
+			if filter.ParentID != nil && (task.ParentID == nil || *task.ParentID != *filter.ParentID) {
+				continue
+			}
+			if filter.RootOnly && task.ParentID != nil {
+				continue
+			}
+			if filter.SeriesID != nil && (task.SeriesID == nil || *task.SeriesID != *filter.SeriesID) {
+				continue
+			}
+			if filter.ProjectID != nil && projectKey(task) != *filter.ProjectID {
 				continue
 			}
 		}
 
 		// Create a copy to avoid race conditions
 		taskCopy := *task
+		taskCopy.Tags = r.taskTagList(task.ID)
+
+		if filter != nil && !matchesTags(taskCopy.Tags, filter) {
+			continue
+		}
+
+		if !query.Match(expr, &taskCopy) {
+			continue
+		}
+
 		tasks = append(tasks, &taskCopy)
 	}
 
-	// Apply sorting
-	if filter != nil && filter.SortBy != "" {
-		sort.Slice(tasks, func(i, j int) bool {
-			switch filter.SortBy {
-			case "title":
-				if filter.SortOrder == "desc" {
-					return tasks[i].Title > tasks[j].Title
-				}
-				return tasks[i].Title < tasks[j].Title
-			case "priority":
-				if filter.SortOrder == "desc" {
-					return tasks[i].Priority > tasks[j].Priority
-				}
-				return tasks[i].Priority < tasks[j].Priority
-			case "due_date":
-				if tasks[i].DueDate == nil && tasks[j].DueDate == nil {
-					return false
-				}
-				if tasks[i].DueDate == nil {
-					return false
-				}
-				if tasks[j].DueDate == nil {
-					return true
-				}
-				if filter.SortOrder == "desc" {
-					return tasks[i].DueDate.After(*tasks[j].DueDate)
-				}
-				return tasks[i].DueDate.Before(*tasks[j].DueDate)
-			case "created_at":
-				fallthrough
-			default:
-				if filter.SortOrder == "desc" {
-					return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+	return tasks, nil
+}
+
+// inTimeRange reports whether t lies in [from, to); a missing t matches only
+// when there are no bounds, like a NULL column in SQL
+func inTimeRange(t, from, to *time.Time) bool {
+	if from == nil && to == nil {
+		return true
+	}
+	if t == nil {
+		return false
+	}
+	return (from == nil || !t.Before(*from)) && (to == nil || t.Before(*to))
+}
+
+// GetDescendants retrieves every task below the given task, parents before children
+func (r *MemoryRepository) GetDescendants(ctx context.Context, id string) ([]*models.Task, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var descendants []*models.Task
+	level := []string{id}
+	for len(level) > 0 {
+		var children []*models.Task
+		for _, task := range r.tasks {
+			if task.ParentID == nil {
+				continue
+			}
+			for _, parentID := range level {
+				if *task.ParentID == parentID {
+					taskCopy := *task
+					taskCopy.Tags = r.taskTagList(task.ID)
+					children = append(children, &taskCopy)
+					break
 				}
-				return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
 			}
+		}
+
+		sort.Slice(children, func(i, j int) bool {
+			return children[i].CreatedAt.Before(children[j].CreatedAt)
 		})
-	} else {
-		// Default sort by created_at desc
-		sort.Slice(tasks, func(i, j int) bool {
-			return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
-		})
+
+		level = level[:0]
+		for _, child := range children {
+			level = append(level, child.ID)
+		}
+		descendants = append(descendants, children...)
 	}
 
-	return tasks, nil
+	return descendants, nil
 }
 
 // Update updates an existing task
@@ -128,11 +249,82 @@ func (r *MemoryRepository) Update(ctx context.Context, task *models.Task) error
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if _, exists := r.tasks[task.ID]; !exists {
-		return fmt.Errorf("task not found")
+	current, exists := r.tasks[task.ID]
+	if !exists {
+		return domain.NotFound("task")
+	}
+
+	// Compare-and-swap on the version the caller read
+	if current.Version != task.Version {
+		currentCopy := *current
+		currentCopy.Tags = r.taskTagList(task.ID)
+		return domain.StaleVersion("task", &currentCopy)
+	}
+
+	task.Version++
+	stored := *task
+	r.tasks[task.ID] = &stored
+	r.setTaskTags(task)
+	r.index.add(&stored)
+	return nil
+}
+
+// Patch copies only the given fields onto the stored task, with the same version check as Update
+func (r *MemoryRepository) Patch(ctx context.Context, task *models.Task, fields []models.TaskField) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	current, exists := r.tasks[task.ID]
+	if !exists {
+		return domain.NotFound("task")
+	}
+
+	if current.Version != task.Version {
+		currentCopy := *current
+		currentCopy.Tags = r.taskTagList(task.ID)
+		return domain.StaleVersion("task", &currentCopy)
 	}
 
-	r.tasks[task.ID] = task
+	stored := *current
+	tags := false
+	for _, field := range fields {
+		switch field {
+		case models.FieldTitle:
+			stored.Title = task.Title
+		case models.FieldDescription:
+			stored.Description = task.Description
+		case models.FieldPriority:
+			stored.Priority = task.Priority
+		case models.FieldStatus:
+			stored.Status = task.Status
+		case models.FieldDueDate:
+			stored.DueDate = task.DueDate
+		case models.FieldProjectID:
+			stored.ProjectID = task.ProjectID
+		case models.FieldParentID:
+			stored.ParentID = task.ParentID
+		case models.FieldRecurrence:
+			stored.Recurrence = task.Recurrence
+			stored.SeriesID = task.SeriesID
+			stored.Occurrence = task.Occurrence
+		case models.FieldDeletedAt:
+			stored.DeletedAt = task.DeletedAt
+		case models.FieldTags:
+			tags = true
+		default:
+			return fmt.Errorf("unknown task field %q", field)
+		}
+	}
+
+	if tags {
+		r.setTaskTags(task)
+	}
+
+	stored.UpdatedAt = task.UpdatedAt
+	stored.Version++
+	r.tasks[task.ID] = &stored
+	r.index.add(&stored)
+	task.Version = stored.Version
 	return nil
 }
 
@@ -142,13 +334,340 @@ func (r *MemoryRepository) Delete(ctx context.Context, id string) error {
 	defer r.mutex.Unlock()
 
 	if _, exists := r.tasks[id]; !exists {
-		return fmt.Errorf("task not found")
+		return domain.NotFound("task")
 	}
 
+	r.remove(id)
+	return nil
+}
+
+// PurgeTrash permanently deletes the tasks trashed at or before the given time
+func (r *MemoryRepository) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var expired []string
+	for id, task := range r.tasks {
+		if task.DeletedAt != nil && !task.DeletedAt.After(before) {
+			expired = append(expired, id)
+		}
+	}
+	for _, id := range expired {
+		r.remove(id)
+	}
+	return len(expired), nil
+}
+
+// remove deletes a stored task with its tag links and reminders; the caller holds the lock
+func (r *MemoryRepository) remove(id string) {
 	delete(r.tasks, id)
+	delete(r.taskTags, id)
+	r.removeReminders(id)
+	r.index.remove(id)
+
+	// Mirror the ON DELETE SET NULL of the Postgres schema
+	for childID, task := range r.tasks {
+		if task.ParentID != nil && *task.ParentID == id {
+			taskCopy := *task
+			taskCopy.ParentID = nil
+			r.tasks[childID] = &taskCopy
+		}
+	}
+}
+
+// CreateTag creates a new tag
+func (r *MemoryRepository) CreateTag(ctx context.Context, tag *models.Tag) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.findTagByName(tag.Name) != nil {
+		return domain.Conflict("tag already exists")
+	}
+
+	tagCopy := *tag
+	r.tags[tag.ID] = &tagCopy
+	return nil
+}
+
+// GetTagByID retrieves a tag by ID
+func (r *MemoryRepository) GetTagByID(ctx context.Context, id string) (*models.Tag, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	tag, exists := r.tags[id]
+	if !exists {
+		return nil, domain.NotFound("tag")
+	}
+
+	tagCopy := *tag
+	return &tagCopy, nil
+}
+
+// GetTagByName retrieves a tag by its case-insensitive name
+func (r *MemoryRepository) GetTagByName(ctx context.Context, name string) (*models.Tag, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	tag := r.findTagByName(name)
+	if tag == nil {
+		return nil, domain.NotFound("tag")
+	}
+
+	tagCopy := *tag
+	return &tagCopy, nil
+}
+
+// GetTags retrieves all tags sorted by name
+func (r *MemoryRepository) GetTags(ctx context.Context) ([]*models.Tag, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	tags := make([]*models.Tag, 0, len(r.tags))
+	for _, tag := range r.tags {
+		tagCopy := *tag
+		tags = append(tags, &tagCopy)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return strings.ToLower(tags[i].Name) < strings.ToLower(tags[j].Name)
+	})
+
+	return tags, nil
+}
+
+// UpdateTag updates an existing tag
+func (r *MemoryRepository) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.tags[tag.ID]; !exists {
+		return domain.NotFound("tag")
+	}
+	if other := r.findTagByName(tag.Name); other != nil && other.ID != tag.ID {
+		return domain.Conflict("tag already exists")
+	}
+
+	tagCopy := *tag
+	r.tags[tag.ID] = &tagCopy
+	return nil
+}
+
+// DeleteTag deletes a tag by ID and detaches it from all tasks
+func (r *MemoryRepository) DeleteTag(ctx context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.tags[id]; !exists {
+		return domain.NotFound("tag")
+	}
+
+	delete(r.tags, id)
+	for taskID, tagIDs := range r.taskTags {
+		kept := tagIDs[:0]
+		for _, tagID := range tagIDs {
+			if tagID != id {
+				kept = append(kept, tagID)
+			}
+		}
+		r.taskTags[taskID] = kept
+	}
+	return nil
+}
+
+// findTagByName looks up a tag by case-insensitive name; the caller must hold the mutex
+func (r *MemoryRepository) findTagByName(name string) *models.Tag {
+	for _, tag := range r.tags {
+		if strings.EqualFold(tag.Name, name) {
+			return tag
+		}
+	}
 	return nil
 }
 
+// setTaskTags records the tag links of a task; the caller must hold the write mutex
+func (r *MemoryRepository) setTaskTags(task *models.Task) {
+	tagIDs := make([]string, 0, len(task.Tags))
+	for _, tag := range task.Tags {
+		if _, exists := r.tags[tag.ID]; exists {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+	}
+	r.taskTags[task.ID] = tagIDs
+}
+
+// taskTagList returns the current tags of a task sorted by name; the caller must hold the mutex
+func (r *MemoryRepository) taskTagList(taskID string) []models.Tag {
+	tags := []models.Tag{}
+	for _, tagID := range r.taskTags[taskID] {
+		if tag, exists := r.tags[tagID]; exists {
+			tags = append(tags, *tag)
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return strings.ToLower(tags[i].Name) < strings.ToLower(tags[j].Name)
+	})
+
+	return tags
+}
+
+// matchesTags reports whether a task's tags satisfy the Tags, AnyTags and AllTags filters
+func matchesTags(tags []models.Tag, filter *models.FilterOptions) bool {
+	names := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		names[strings.ToLower(tag.Name)] = true
+	}
+
+	if len(filter.AnyTags) > 0 {
+		found := false
+		for _, name := range lowerNames(filter.AnyTags) {
+			if names[name] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for _, name := range lowerNames(filter.AllTags) {
+		if !names[name] {
+			return false
+		}
+	}
+
+	if filter.Tags != nil {
+		wanted := lowerNames(filter.Tags)
+		if len(wanted) != len(names) {
+			return false
+		}
+		for _, name := range wanted {
+			if !names[name] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// CreateProject creates a new project
+func (r *MemoryRepository) CreateProject(ctx context.Context, project *models.Project) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	projectCopy := *project
+	r.projects[project.ID] = &projectCopy
+	return nil
+}
+
+// GetProjectByID retrieves a project by ID
+func (r *MemoryRepository) GetProjectByID(ctx context.Context, id string) (*models.Project, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	project, exists := r.projects[id]
+	if !exists {
+		return nil, domain.NotFound("project")
+	}
+
+	projectCopy := *project
+	return &projectCopy, nil
+}
+
+// GetProjects retrieves projects by sort order, optionally including archived ones
+func (r *MemoryRepository) GetProjects(ctx context.Context, includeArchived bool) ([]*models.Project, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	projects := []*models.Project{}
+	for _, project := range r.projects {
+		if project.Archived && !includeArchived {
+			continue
+		}
+		projectCopy := *project
+		projects = append(projects, &projectCopy)
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		if projects[i].SortOrder != projects[j].SortOrder {
+			return projects[i].SortOrder < projects[j].SortOrder
+		}
+		return strings.ToLower(projects[i].Name) < strings.ToLower(projects[j].Name)
+	})
+
+	return projects, nil
+}
+
+// UpdateProject updates an existing project
+func (r *MemoryRepository) UpdateProject(ctx context.Context, project *models.Project) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.projects[project.ID]; !exists {
+		return domain.NotFound("project")
+	}
+
+	projectCopy := *project
+	r.projects[project.ID] = &projectCopy
+	return nil
+}
+
+// DeleteProject deletes a project; its tasks are kept without a project
+func (r *MemoryRepository) DeleteProject(ctx context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.projects[id]; !exists {
+		return domain.NotFound("project")
+	}
+
+	delete(r.projects, id)
+
+	// Mirror the ON DELETE SET NULL of the Postgres schema
+	for taskID, task := range r.tasks {
+		if task.ProjectID != nil && *task.ProjectID == id {
+			taskCopy := *task
+			taskCopy.ProjectID = nil
+			r.tasks[taskID] = &taskCopy
+		}
+	}
+	return nil
+}
+
+// GetProjectTaskCounts counts tasks per project ID, using "" for tasks without a project
+func (r *MemoryRepository) GetProjectTaskCounts(ctx context.Context) (map[string]models.TaskCounts, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	counts := make(map[string]models.TaskCounts)
+	for _, task := range r.tasks {
+		if task.DeletedAt != nil {
+			continue
+		}
+		key := projectKey(task)
+		c := counts[key]
+		c.Total++
+		if task.Status == models.StatusCompleted {
+			c.Completed++
+		} else {
+			c.Active++
+		}
+		counts[key] = c
+	}
+
+	return counts, nil
+}
+
+// projectKey returns the project ID of a task or "" when it has none
+func projectKey(task *models.Task) string {
+	if task.ProjectID == nil {
+		return ""
+	}
+	return *task.ProjectID
+}
+
 // Close closes the repository (no-op for memory repository)
 func (r *MemoryRepository) Close() error {
 	return nil
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"sort"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// Word weights of the title and the description, matching the default
+// ts_rank weights of the A and B labels used by the Postgres search column
+const (
+	titleWeight       = 1.0
+	descriptionWeight = 0.4
+)
+
+// searchIndex is an inverted index from words to the tasks containing them
+type searchIndex struct {
+	postings map[string]map[string]float64 // word -> task ID -> weight
+	words    map[string][]string           // task ID -> indexed words, for removal
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings: make(map[string]map[string]float64),
+		words:    make(map[string][]string),
+	}
+}
+
+// add indexes the title and description of a task, replacing its previous entry
+func (ix *searchIndex) add(task *models.Task) {
+	ix.remove(task.ID)
+
+	weights := make(map[string]float64)
+	for _, word := range models.Tokenize(task.Title) {
+		weights[word] += titleWeight
+	}
+	for _, word := range models.Tokenize(task.Description) {
+		weights[word] += descriptionWeight
+	}
+
+	for word, weight := range weights {
+		posting, ok := ix.postings[word]
+		if !ok {
+			posting = make(map[string]float64)
+			ix.postings[word] = posting
+		}
+		posting[task.ID] = weight
+		ix.words[task.ID] = append(ix.words[task.ID], word)
+	}
+}
+
+// remove drops a task from the index
+func (ix *searchIndex) remove(id string) {
+	for _, word := range ix.words[id] {
+		delete(ix.postings[word], id)
+		if len(ix.postings[word]) == 0 {
+			delete(ix.postings, word)
+		}
+	}
+	delete(ix.words, id)
+}
+
+// scores returns the tasks containing a word for every term, each with the
+// summed weight of its matching words
+func (ix *searchIndex) scores(terms []string) map[string]float64 {
+	var result map[string]float64
+	for _, term := range terms {
+		termScores := make(map[string]float64)
+		for word, posting := range ix.postings {
+			if !models.MatchesTerm(word, term) {
+				continue
+			}
+			for id, weight := range posting {
+				termScores[id] += weight
+			}
+		}
+
+		if result == nil {
+			result = termScores
+			continue
+		}
+		for id := range result {
+			if score, ok := termScores[id]; ok {
+				result[id] += score
+			} else {
+				delete(result, id)
+			}
+		}
+	}
+	return result
+}
+
+// Search retrieves the tasks matching filter.Query, most relevant first
+func (r *MemoryRepository) Search(ctx context.Context, filter *models.FilterOptions) ([]*models.SearchResult, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	tasks, err := r.filterTasks(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := r.index.scores(models.Tokenize(filter.Query))
+
+	var results []*models.SearchResult
+	for _, task := range tasks {
+		results = append(results, &models.SearchResult{Task: task, Rank: scores[task.ID]})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		if !results[i].Task.CreatedAt.Equal(results[j].Task.CreatedAt) {
+			return results[i].Task.CreatedAt.After(results[j].Task.CreatedAt)
+		}
+		return results[i].Task.ID < results[j].Task.ID
+	})
+
+	return results, nil
+}
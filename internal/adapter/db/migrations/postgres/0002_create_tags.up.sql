@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS tags (
+	id VARCHAR(36) PRIMARY KEY,
+	name VARCHAR(64) NOT NULL,
+	color VARCHAR(16) NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tags(LOWER(name));
+
+CREATE TABLE IF NOT EXISTS task_tags (
+	task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	tag_id VARCHAR(36) NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+	PRIMARY KEY (task_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_tags_tag_id ON task_tags(tag_id);
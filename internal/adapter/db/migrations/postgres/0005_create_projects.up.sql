@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS projects (
+	id VARCHAR(36) PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	color VARCHAR(16) NOT NULL DEFAULT '',
+	archived BOOLEAN NOT NULL DEFAULT FALSE,
+	sort_order INTEGER NOT NULL DEFAULT 0,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id VARCHAR(36) REFERENCES projects(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks(project_id);
@@ -0,0 +1,7 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search tsvector
+	GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', COALESCE(title, '')), 'A') ||
+		setweight(to_tsvector('simple', COALESCE(description, '')), 'B')
+	) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_search ON tasks USING GIN (search);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS task_events (
+	id BIGSERIAL PRIMARY KEY,
+	task_id VARCHAR(36) NOT NULL,
+	action VARCHAR(20) NOT NULL,
+	actor VARCHAR(255) NOT NULL DEFAULT '',
+	via VARCHAR(36) NOT NULL DEFAULT '',
+	changes JSONB NOT NULL DEFAULT '[]',
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_task_id ON task_events(task_id, id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS task_reminders (
+	id VARCHAR(36) PRIMARY KEY,
+	task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	offset_minutes INTEGER NOT NULL,
+	snoozed_until TIMESTAMP,
+	fired_for TIMESTAMP,
+	dismissed_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_reminders_task_id ON task_reminders(task_id, offset_minutes);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS feeds (
+	id VARCHAR(36) PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	token VARCHAR(64) NOT NULL,
+	filter JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feeds_token ON feeds(token);
@@ -0,0 +1,2 @@
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks(deleted_at);
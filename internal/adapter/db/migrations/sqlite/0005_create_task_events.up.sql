@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS task_events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	task_id TEXT NOT NULL,
+	action TEXT NOT NULL,
+	actor TEXT NOT NULL DEFAULT '',
+	via TEXT NOT NULL DEFAULT '',
+	changes TEXT NOT NULL DEFAULT '[]',
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_task_id ON task_events(task_id, id);
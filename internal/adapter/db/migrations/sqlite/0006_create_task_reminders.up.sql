@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS task_reminders (
+	id TEXT PRIMARY KEY,
+	task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	offset_minutes INTEGER NOT NULL,
+	snoozed_until TIMESTAMP,
+	fired_for TIMESTAMP,
+	dismissed_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_reminders_task_id ON task_reminders(task_id, offset_minutes);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS feeds (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	token TEXT NOT NULL,
+	filter TEXT NOT NULL DEFAULT '{}',
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feeds_token ON feeds(token);
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"log"
+	"os"
+
+	"todo-wails-go/internal/domain/ports"
+)
+
+// defaultConnStr is the Postgres connection used for local development
+const defaultConnStr = "host=localhost port=5432 user=postgres password=postgres dbname=todo_app sslmode=disable"
+
+// Open selects the task store the same way for every entry point: PostgreSQL
+// from DATABASE_URL, then the SQLite file (TODO_SQLITE_PATH or the user's config
+// directory), and in-memory storage only when neither can be opened.
+func Open() ports.TaskRepository {
+	connStr := os.Getenv("DATABASE_URL")
+	if connStr == "" {
+		connStr = defaultConnStr
+	}
+
+	repo, err := NewPostgresRepository(connStr)
+	if err == nil {
+		return repo
+	}
+	log.Printf("Warning: Failed to connect to PostgreSQL: %v", err)
+
+	repo, err = openSQLite()
+	if err == nil {
+		return repo
+	}
+	log.Printf("Warning: Failed to open SQLite database: %v", err)
+
+	log.Println("Using in-memory storage instead, tasks will be lost on exit")
+	return NewMemoryRepository()
+}
+
+// openSQLite opens the offline SQLite database
+func openSQLite() (ports.TaskRepository, error) {
+	path := os.Getenv("TODO_SQLITE_PATH")
+	if path == "" {
+		var err error
+		path, err = DefaultSQLitePath()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	repo, err := NewSQLiteRepository(path)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Using SQLite storage at %s", path)
+	return repo, nil
+}
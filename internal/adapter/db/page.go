@@ -0,0 +1,182 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+)
+
+// sortColumns maps the whitelisted sort fields to their columns; nothing
+// from a request ever reaches ORDER BY without passing through it
+var sortColumns = map[models.SortField]string{
+	models.SortCreatedAt: "created_at",
+	models.SortUpdatedAt: "updated_at",
+	models.SortDueDate:   "due_date",
+	models.SortPriority:  "priority",
+	models.SortStatus:    "status",
+	models.SortTitle:     "title",
+}
+
+// taskPage validates the ordering and the page cursor of a filter
+func taskPage(filter *models.FilterOptions) ([]models.SortKey, *models.PageCursor, error) {
+	keys, err := filter.SortKeys()
+	if err != nil {
+		return nil, nil, domain.Invalid("sort", "%v", err)
+	}
+
+	if filter == nil {
+		return keys, nil, nil
+	}
+
+	cursor, err := models.ParseCursor(filter.Cursor, keys)
+	if err != nil {
+		return nil, nil, domain.Invalid("cursor", "%v", err)
+	}
+	return keys, cursor, nil
+}
+
+// sortColumn returns the SQL expression of a sort field. Titles compare
+// byte-wise in every dialect, like strings.Compare in MemoryRepository.
+func (b *whereBuilder) sortColumn(field models.SortField) string {
+	column := sortColumns[field]
+	if field == models.SortTitle {
+		column += b.dialect.binaryCollation
+	}
+	return column
+}
+
+// cursorValue returns the cursor's value of a sort field, nil for no due date
+func cursorValue(cursor *models.PageCursor, field models.SortField) interface{} {
+	switch field {
+	case models.SortDueDate:
+		if cursor.DueDate == nil {
+			return nil
+		}
+		return *cursor.DueDate
+	case models.SortPriority:
+		return cursor.Priority
+	case models.SortStatus:
+		return cursor.Status
+	case models.SortTitle:
+		return cursor.Title
+	case models.SortUpdatedAt:
+		return cursor.UpdatedAt
+	default:
+		return cursor.CreatedAt
+	}
+}
+
+// orderBy builds the ORDER BY clause of the keys, ending with the ID so that
+// the order is total and keyset pagination is stable
+func (b *whereBuilder) orderBy(keys []models.SortKey) string {
+	columns := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		direction := " ASC"
+		if key.Desc() {
+			direction = " DESC"
+		}
+		// The direction has to precede NULLS LAST
+		if key.Field == models.SortDueDate {
+			direction += " NULLS LAST"
+		}
+		columns = append(columns, b.sortColumn(key.Field)+direction)
+	}
+	return " ORDER BY " + strings.Join(append(columns, "id ASC"), ", ")
+}
+
+// keyset adds the condition selecting the tasks ordered after the cursor:
+// for keys k1..kn and ID, some ki lies after the cursor while all keys
+// before it are equal, or all keys are equal and the ID is greater.
+// Missing due dates sort last in both directions, as in orderBy.
+func (b *whereBuilder) keyset(cursor *models.PageCursor, keys []models.SortKey) {
+	var alternatives []string
+	for i, key := range keys {
+		value := cursorValue(cursor, key.Field)
+		if value == nil {
+			continue // nothing sorts after a missing due date but greater IDs
+		}
+		// Arguments are registered in text order for positional placeholders
+		parts := b.sortEqual(cursor, keys[:i])
+		parts = append(parts, b.sortAfter(key, value))
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+	}
+	parts := b.sortEqual(cursor, keys)
+	parts = append(parts, "id > "+b.arg(cursor.ID))
+	alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+
+	b.add("(" + strings.Join(alternatives, " OR ") + ")")
+}
+
+// sortEqual returns conditions matching the cursor's value of every key
+func (b *whereBuilder) sortEqual(cursor *models.PageCursor, keys []models.SortKey) []string {
+	parts := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		if value := cursorValue(cursor, key.Field); value == nil {
+			parts = append(parts, b.sortColumn(key.Field)+" IS NULL")
+		} else {
+			parts = append(parts, b.sortColumn(key.Field)+" = "+b.arg(value))
+		}
+	}
+	return parts
+}
+
+// sortAfter returns the condition for a column lying after a non-nil value
+// in the key's direction
+func (b *whereBuilder) sortAfter(key models.SortKey, value interface{}) string {
+	op := ">"
+	if key.Desc() {
+		op = "<"
+	}
+	if key.Field == models.SortDueDate {
+		return fmt.Sprintf("(due_date IS NULL OR due_date %s %s)", op, b.arg(value))
+	}
+	return fmt.Sprintf("%s %s %s", b.sortColumn(key.Field), op, b.arg(value))
+}
+
+// compareTasks orders two tasks by the sort keys and then by ID, exactly as
+// the ORDER BY of the SQL repositories does
+func compareTasks(a, b *models.Task, keys []models.SortKey) int {
+	for _, key := range keys {
+		c := compareField(a, b, key.Field)
+		if c == 0 {
+			continue
+		}
+		// Missing due dates stay last regardless of the direction
+		if key.Field == models.SortDueDate && (a.DueDate == nil || b.DueDate == nil) {
+			return c
+		}
+		if key.Desc() {
+			return -c
+		}
+		return c
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+// compareField compares one sort field of two tasks in ascending order
+func compareField(a, b *models.Task, field models.SortField) int {
+	switch field {
+	case models.SortTitle:
+		return strings.Compare(a.Title, b.Title)
+	case models.SortPriority:
+		return int(a.Priority) - int(b.Priority)
+	case models.SortStatus:
+		return int(a.Status) - int(b.Status)
+	case models.SortDueDate:
+		switch {
+		case a.DueDate == nil && b.DueDate == nil:
+			return 0
+		case a.DueDate == nil:
+			return 1
+		case b.DueDate == nil:
+			return -1
+		}
+		return a.DueDate.Compare(*b.DueDate)
+	case models.SortUpdatedAt:
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	default:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	}
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// taskFieldColumns maps patchable task fields to their columns; tags live in task_tags
+var taskFieldColumns = map[models.TaskField]string{
+	models.FieldTitle:       "title",
+	models.FieldDescription: "description",
+	models.FieldPriority:    "priority",
+	models.FieldStatus:      "status",
+	models.FieldDueDate:     "due_date",
+	models.FieldProjectID:   "project_id",
+	models.FieldParentID:    "parent_id",
+	models.FieldRecurrence:  "recurrence",
+	models.FieldDeletedAt:   "deleted_at",
+}
+
+// taskFieldValue returns the column value of a patchable field
+func taskFieldValue(task *models.Task, field models.TaskField) interface{} {
+	switch field {
+	case models.FieldTitle:
+		return task.Title
+	case models.FieldDescription:
+		return task.Description
+	case models.FieldPriority:
+		return task.Priority
+	case models.FieldStatus:
+		return task.Status
+	case models.FieldDueDate:
+		return task.DueDate
+	case models.FieldProjectID:
+		return task.ProjectID
+	case models.FieldParentID:
+		return task.ParentID
+	case models.FieldRecurrence:
+		return recurrenceRule(task)
+	case models.FieldDeletedAt:
+		return task.DeletedAt
+	}
+	return nil
+}
+
+// buildTaskPatch returns a version-guarded UPDATE that writes only the given
+// fields of the task, and whether the task's tag links must be rewritten
+func buildTaskPatch(task *models.Task, fields []models.TaskField, dialect sqlDialect) (string, []interface{}, bool, error) {
+	b := &whereBuilder{dialect: dialect}
+	var sets []string
+	tags := false
+
+	for _, field := range fields {
+		if field == models.FieldTags {
+			tags = true
+			continue
+		}
+		column, ok := taskFieldColumns[field]
+		if !ok {
+			return "", nil, false, fmt.Errorf("unknown task field %q", field)
+		}
+		sets = append(sets, column+" = "+b.arg(taskFieldValue(task, field)))
+		if field == models.FieldRecurrence {
+			// A rule comes with the task's place in its series
+			sets = append(sets, "series_id = "+b.arg(task.SeriesID), "occurrence = "+b.arg(task.Occurrence))
+		}
+	}
+
+	sets = append(sets, "updated_at = "+b.arg(task.UpdatedAt), "version = version + 1")
+	b.add("id = " + b.arg(task.ID))
+	b.add("version = " + b.arg(task.Version))
+
+	return "UPDATE tasks SET " + strings.Join(sets, ", ") + b.where(), b.args, tags, nil
+}
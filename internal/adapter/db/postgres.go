@@ -3,13 +3,18 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
+	"todo-wails-go/internal/adapter/db/migrations"
+	"todo-wails-go/internal/domain"
 	"todo-wails-go/internal/domain/models"
 	"todo-wails-go/internal/domain/ports"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // PostgresRepository implements TaskRepository interface
@@ -17,6 +22,65 @@ type PostgresRepository struct {
 	db *sql.DB
 }
 
+// taskColumns is the column list read by scanTask
+const taskColumns = "id, title, description, priority, status, due_date, parent_id, project_id, recurrence, series_id, occurrence, version, created_at, updated_at, deleted_at"
+
+// qualifiedTaskColumns prefixes every column of taskColumns with a table alias
+func qualifiedTaskColumns(alias string) string {
+	columns := strings.Split(taskColumns, ", ")
+	for i, column := range columns {
+		columns[i] = alias + "." + column
+	}
+	return strings.Join(columns, ", ")
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTask reads a task selected with taskColumns, followed by any extra columns
+func scanTask(row rowScanner, extra ...interface{}) (*models.Task, error) {
+	task := &models.Task{}
+	var dueDate, deletedAt sql.NullTime
+	var parentID, projectID, seriesID sql.NullString
+	var recurrence string
+
+	dest := []interface{}{
+		&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status,
+		&dueDate, &parentID, &projectID, &recurrence, &seriesID, &task.Occurrence,
+		&task.Version, &task.CreatedAt, &task.UpdatedAt, &deletedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if dueDate.Valid {
+		task.DueDate = &dueDate.Time
+	}
+	if deletedAt.Valid {
+		task.DeletedAt = &deletedAt.Time
+	}
+	if parentID.Valid {
+		task.ParentID = &parentID.String
+	}
+	if projectID.Valid {
+		task.ProjectID = &projectID.String
+	}
+	if seriesID.Valid {
+		task.SeriesID = &seriesID.String
+	}
+	if recurrence != "" {
+		task.Recurrence, err = models.ParseRecurrence(recurrence)
+		if err != nil {
+			return nil, fmt.Errorf("task %s: %w", task.ID, err)
+		}
+	}
+
+	return task, nil
+}
+
 // NewPostgresRepository creates a new PostgreSQL repository
 func NewPostgresRepository(connStr string) (ports.TaskRepository, error) {
 	db, err := sql.Open("postgres", connStr)
@@ -29,77 +93,75 @@ func NewPostgresRepository(connStr string) (ports.TaskRepository, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	repo := &PostgresRepository{db: db}
-
-	// Create table if not exists
-	if err := repo.createTable(); err != nil {
-		return nil, fmt.Errorf("failed to create table: %w", err)
+	// Bring the schema up to date
+	if err := migrate(db, migrations.Postgres); err != nil {
+		db.Close()
+		return nil, err
 	}
 
-	return repo, nil
+	return &PostgresRepository{db: db}, nil
 }
 
-// createTable creates the tasks table if it doesn't exist
-func (r *PostgresRepository) createTable() error {
-	query := `
-	CREATE TABLE IF NOT EXISTS tasks (
-		id VARCHAR(36) PRIMARY KEY,
-		title VARCHAR(255) NOT NULL,
-		description TEXT,
-		priority INTEGER NOT NULL DEFAULT 0,
-		status INTEGER NOT NULL DEFAULT 0,
-		due_date TIMESTAMP,
-		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-		updated_at TIMESTAMP NOT NULL DEFAULT NOW()
-	);
-	
-	CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
-	CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks(priority);
-	CREATE INDEX IF NOT EXISTS idx_tasks_created_at ON tasks(created_at);
-	CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks(due_date);
-	`
+// migrate applies pending schema migrations
+func migrate(db *sql.DB, dialect migrations.Dialect) error {
+	migrator, err := migrations.New(db, dialect)
+	if err != nil {
+		return err
+	}
 
-	_, err := r.db.Exec(query)
-	return err
+	applied, err := migrator.Up(context.Background())
+	for _, migration := range applied {
+		log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return nil
 }
 
 // Create creates a new task
 func (r *PostgresRepository) Create(ctx context.Context, task *models.Task) error {
 	query := `
-		INSERT INTO tasks (id, title, description, priority, status, due_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO tasks (` + taskColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, query,
 		task.ID, task.Title, task.Description, task.Priority, task.Status,
-		task.DueDate, task.CreatedAt, task.UpdatedAt)
+		task.DueDate, task.ParentID, task.ProjectID, recurrenceRule(task), task.SeriesID, task.Occurrence,
+		task.Version, task.CreatedAt, task.UpdatedAt, task.DeletedAt)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if err := r.saveTaskTags(ctx, tx, task); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // GetByID retrieves a task by ID
 func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*models.Task, error) {
-	query := `
-		SELECT id, title, description, priority, status, due_date, created_at, updated_at
-		FROM tasks WHERE id = $1
-	`
-
-	task := &models.Task{}
-	var dueDate sql.NullTime
-
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status,
-		&dueDate, &task.CreatedAt, &task.UpdatedAt)
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = $1"
 
+	task, err := scanTask(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("task not found")
+			return nil, domain.NotFound("task")
 		}
 		return nil, err
 	}
 
-	if dueDate.Valid {
-		task.DueDate = &dueDate.Time
+	if err := r.loadTags(ctx, []*models.Task{task}); err != nil {
+		return nil, err
 	}
 
 	return task, nil
@@ -107,84 +169,116 @@ func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*models.Ta
 
 // GetAll retrieves all tasks with optional filtering and sorting
 func (r *PostgresRepository) GetAll(ctx context.Context, filter *models.FilterOptions) ([]*models.Task, error) {
-	query := "SELECT id, title, description, priority, status, due_date, created_at, updated_at FROM tasks"
-	args := []interface{}{}
-	argIndex := 1
+	where, err := buildTaskFilter(filter, postgresDialect)
+	if err != nil {
+		return nil, err
+	}
+	keys, cursor, err := taskPage(filter)
+	if err != nil {
+		return nil, err
+	}
+	if cursor != nil {
+		where.keyset(cursor, keys)
+	}
+	query := "SELECT " + taskColumns + " FROM tasks" + where.where() + where.orderBy(keys) + taskLimit(filter)
 
-	// Build WHERE clause
-	whereClauses := []string{}
+	rows, err := r.db.QueryContext(ctx, query, where.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	if filter != nil {
-		if filter.Status != nil {
-			whereClauses = append(whereClauses, fmt.Sprintf("status = $%d", argIndex))
-			args = append(args, *filter.Status)
-			argIndex++
-		}
+	return r.scanTasks(ctx, rows)
+}
 
-		if filter.Priority != nil {
-			whereClauses = append(whereClauses, fmt.Sprintf("priority = $%d", argIndex))
-			args = append(args, *filter.Priority)
-			argIndex++
-		}
+// Count returns the number of tasks matching filter, ignoring its page
+func (r *PostgresRepository) Count(ctx context.Context, filter *models.FilterOptions) (int, error) {
+	where, err := buildTaskFilter(filter, postgresDialect)
+	if err != nil {
+		return 0, err
+	}
 
-		if filter.DateFrom != nil {
-			whereClauses = append(whereClauses, fmt.Sprintf("created_at >= $%d", argIndex))
-			args = append(args, *filter.DateFrom)
-			argIndex++
-		}
+	var count int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM tasks"+where.where(), where.args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
 
-		if filter.DateTo != nil {
-			whereClauses = append(whereClauses, fmt.Sprintf("created_at <= $%d", argIndex))
-			args = append(args, *filter.DateTo)
-			argIndex++
-		}
+// Search retrieves the tasks matching filter.Query, most relevant first
+func (r *PostgresRepository) Search(ctx context.Context, filter *models.FilterOptions) ([]*models.SearchResult, error) {
+	query, args, err := buildTaskSearch(filter, postgresDialect)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(whereClauses) > 0 {
-		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	// Build ORDER BY clause
-	if filter != nil && filter.SortBy != "" {
-		orderBy := filter.SortBy
-		if orderBy == "due_date" {
-			orderBy = "due_date NULLS LAST"
+	var results []*models.SearchResult
+	var tasks []*models.Task
+	for rows.Next() {
+		result := &models.SearchResult{}
+		result.Task, err = scanTask(rows, &result.Rank)
+		if err != nil {
+			return nil, err
 		}
-		query += " ORDER BY " + orderBy
+		results = append(results, result)
+		tasks = append(tasks, result.Task)
+	}
 
-		if filter.SortOrder == "desc" {
-			query += " DESC"
-		} else {
-			query += " ASC"
-		}
-	} else {
-		query += " ORDER BY created_at DESC"
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err := r.loadTags(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetDescendants retrieves every task below the given task, parents before children
+func (r *PostgresRepository) GetDescendants(ctx context.Context, id string) ([]*models.Task, error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT ` + taskColumns + `, 1 AS depth FROM tasks WHERE parent_id = $1
+			UNION ALL
+			SELECT ` + qualifiedTaskColumns("t") + `, s.depth + 1
+			FROM tasks t JOIN subtree s ON t.parent_id = s.id
+		)
+		SELECT ` + taskColumns + ` FROM subtree ORDER BY depth, created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	return r.scanTasks(ctx, rows)
+}
+
+// scanTasks reads all rows selected with taskColumns and loads their tags
+func (r *PostgresRepository) scanTasks(ctx context.Context, rows *sql.Rows) ([]*models.Task, error) {
 	var tasks []*models.Task
 	for rows.Next() {
-		task := &models.Task{}
-		var dueDate sql.NullTime
-
-		err := rows.Scan(
-			&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status,
-			&dueDate, &task.CreatedAt, &task.UpdatedAt)
-
+		task, err := scanTask(rows)
 		if err != nil {
 			return nil, err
 		}
+		tasks = append(tasks, task)
+	}
 
-		if dueDate.Valid {
-			task.DueDate = &dueDate.Time
-		}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-		tasks = append(tasks, task)
+	if err := r.loadTags(ctx, tasks); err != nil {
+		return nil, err
 	}
 
 	return tasks, nil
@@ -194,24 +288,339 @@ func (r *PostgresRepository) GetAll(ctx context.Context, filter *models.FilterOp
 func (r *PostgresRepository) Update(ctx context.Context, task *models.Task) error {
 	query := `
 		UPDATE tasks 
-		SET title = $2, description = $3, priority = $4, status = $5, due_date = $6, updated_at = $7
-		WHERE id = $1
+		SET title = $2, description = $3, priority = $4, status = $5, due_date = $6, parent_id = $7,
+			project_id = $8, recurrence = $9, series_id = $10, occurrence = $11, updated_at = $12,
+			deleted_at = $14, version = version + 1
+		WHERE id = $1 AND version = $13
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query,
 		task.ID, task.Title, task.Description, task.Priority, task.Status,
-		task.DueDate, task.UpdatedAt)
+		task.DueDate, task.ParentID, task.ProjectID, recurrenceRule(task), task.SeriesID, task.Occurrence,
+		task.UpdatedAt, task.Version, task.DeletedAt)
+	if err := affected(result, err, "task"); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			tx.Rollback()
+			return versionConflict(ctx, r, task.ID)
+		}
+		return err
+	}
 
-	return err
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = $1", task.ID); err != nil {
+		return err
+	}
+
+	if err := r.saveTaskTags(ctx, tx, task); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	task.Version++
+	return nil
+}
+
+// Patch writes only the given fields of a task, with the same version check as Update
+func (r *PostgresRepository) Patch(ctx context.Context, task *models.Task, fields []models.TaskField) error {
+	query, args, tags, err := buildTaskPatch(task, fields, postgresDialect)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err := affected(result, err, "task"); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			tx.Rollback()
+			return versionConflict(ctx, r, task.ID)
+		}
+		return err
+	}
+
+	if tags {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = $1", task.ID); err != nil {
+			return err
+		}
+		if err := r.saveTaskTags(ctx, tx, task); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	task.Version++
+	return nil
 }
 
 // Delete deletes a task by ID
 func (r *PostgresRepository) Delete(ctx context.Context, id string) error {
 	query := "DELETE FROM tasks WHERE id = $1"
-	_, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, id)
+	return affected(result, err, "task")
+}
+
+// PurgeTrash permanently deletes the tasks trashed at or before the given time
+func (r *PostgresRepository) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	query := "DELETE FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at <= $1"
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// recurrenceRule returns the RRULE stored in the recurrence column
+func recurrenceRule(task *models.Task) string {
+	if task.Recurrence == nil {
+		return ""
+	}
+	return task.Recurrence.String()
+}
+
+// saveTaskTags inserts the task-to-tag links of a task
+func (r *PostgresRepository) saveTaskTags(ctx context.Context, tx *sql.Tx, task *models.Task) error {
+	for _, tag := range task.Tags {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO task_tags (task_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+			task.ID, tag.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadTags fills the Tags field of the given tasks
+func (r *PostgresRepository) loadTags(ctx context.Context, tasks []*models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.Task, len(tasks))
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		task.Tags = []models.Tag{}
+		byID[task.ID] = task
+		ids = append(ids, task.ID)
+	}
+
+	query := `
+		SELECT tt.task_id, tg.id, tg.name, tg.color, tg.created_at
+		FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+		WHERE tt.task_id = ANY($1)
+		ORDER BY LOWER(tg.name)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID string
+		var tag models.Tag
+		if err := rows.Scan(&taskID, &tag.ID, &tag.Name, &tag.Color, &tag.CreatedAt); err != nil {
+			return err
+		}
+		if task, ok := byID[taskID]; ok {
+			task.Tags = append(task.Tags, tag)
+		}
+	}
+
+	return rows.Err()
+}
+
+// CreateTag creates a new tag
+func (r *PostgresRepository) CreateTag(ctx context.Context, tag *models.Tag) error {
+	query := "INSERT INTO tags (id, name, color, created_at) VALUES ($1, $2, $3, $4)"
+	_, err := r.db.ExecContext(ctx, query, tag.ID, tag.Name, tag.Color, tag.CreatedAt)
+	return uniqueViolation(err, "tag already exists")
+}
+
+// GetTagByID retrieves a tag by ID
+func (r *PostgresRepository) GetTagByID(ctx context.Context, id string) (*models.Tag, error) {
+	query := "SELECT id, name, color, created_at FROM tags WHERE id = $1"
+	return r.getTag(ctx, query, id)
+}
+
+// GetTagByName retrieves a tag by its case-insensitive name
+func (r *PostgresRepository) GetTagByName(ctx context.Context, name string) (*models.Tag, error) {
+	query := "SELECT id, name, color, created_at FROM tags WHERE LOWER(name) = LOWER($1)"
+	return r.getTag(ctx, query, name)
+}
+
+// getTag runs a single-tag query
+func (r *PostgresRepository) getTag(ctx context.Context, query string, arg interface{}) (*models.Tag, error) {
+	tag := &models.Tag{}
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(&tag.ID, &tag.Name, &tag.Color, &tag.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NotFound("tag")
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
+// GetTags retrieves all tags sorted by name
+func (r *PostgresRepository) GetTags(ctx context.Context) ([]*models.Tag, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, color, created_at FROM tags ORDER BY LOWER(name)")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []*models.Tag{}
+	for rows.Next() {
+		tag := &models.Tag{}
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Color, &tag.CreatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// UpdateTag updates an existing tag
+func (r *PostgresRepository) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	query := "UPDATE tags SET name = $2, color = $3 WHERE id = $1"
+	result, err := r.db.ExecContext(ctx, query, tag.ID, tag.Name, tag.Color)
+	return uniqueViolation(affected(result, err, "tag"), "tag already exists")
+}
+
+// DeleteTag deletes a tag by ID; its task links are removed by the foreign key cascade
+func (r *PostgresRepository) DeleteTag(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM tags WHERE id = $1", id)
+	return affected(result, err, "tag")
+}
+
+// projectColumns is the column list read by scanProject
+const projectColumns = "id, name, color, archived, sort_order, created_at, updated_at"
+
+// scanProject reads a project selected with projectColumns
+func scanProject(row rowScanner) (*models.Project, error) {
+	project := &models.Project{}
+	err := row.Scan(&project.ID, &project.Name, &project.Color, &project.Archived,
+		&project.SortOrder, &project.CreatedAt, &project.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// CreateProject creates a new project
+func (r *PostgresRepository) CreateProject(ctx context.Context, project *models.Project) error {
+	query := "INSERT INTO projects (" + projectColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7)"
+	_, err := r.db.ExecContext(ctx, query,
+		project.ID, project.Name, project.Color, project.Archived,
+		project.SortOrder, project.CreatedAt, project.UpdatedAt)
 	return err
 }
 
+// GetProjectByID retrieves a project by ID
+func (r *PostgresRepository) GetProjectByID(ctx context.Context, id string) (*models.Project, error) {
+	query := "SELECT " + projectColumns + " FROM projects WHERE id = $1"
+
+	project, err := scanProject(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NotFound("project")
+		}
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// GetProjects retrieves projects by sort order, optionally including archived ones
+func (r *PostgresRepository) GetProjects(ctx context.Context, includeArchived bool) ([]*models.Project, error) {
+	query := "SELECT " + projectColumns + " FROM projects"
+	if !includeArchived {
+		query += " WHERE NOT archived"
+	}
+	query += " ORDER BY sort_order, LOWER(name)"
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects := []*models.Project{}
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+// UpdateProject updates an existing project
+func (r *PostgresRepository) UpdateProject(ctx context.Context, project *models.Project) error {
+	query := `
+		UPDATE projects
+		SET name = $2, color = $3, archived = $4, sort_order = $5, updated_at = $6
+		WHERE id = $1
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		project.ID, project.Name, project.Color, project.Archived, project.SortOrder, project.UpdatedAt)
+	return affected(result, err, "project")
+}
+
+// DeleteProject deletes a project; its tasks are kept without a project by the foreign key
+func (r *PostgresRepository) DeleteProject(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM projects WHERE id = $1", id)
+	return affected(result, err, "project")
+}
+
+// GetProjectTaskCounts counts tasks per project ID, using "" for tasks without a project
+func (r *PostgresRepository) GetProjectTaskCounts(ctx context.Context) (map[string]models.TaskCounts, error) {
+	query := `
+		SELECT COALESCE(project_id, ''), COUNT(*),
+			COUNT(*) FILTER (WHERE status = $1), COUNT(*) FILTER (WHERE status = $2)
+		FROM tasks WHERE deleted_at IS NULL GROUP BY project_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, models.StatusActive, models.StatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]models.TaskCounts)
+	for rows.Next() {
+		var projectID string
+		var c models.TaskCounts
+		if err := rows.Scan(&projectID, &c.Total, &c.Active, &c.Completed); err != nil {
+			return nil, err
+		}
+		counts[projectID] = c
+	}
+
+	return counts, rows.Err()
+}
+
 // Close closes the database connection
 func (r *PostgresRepository) Close() error {
 	return r.db.Close()
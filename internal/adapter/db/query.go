@@ -0,0 +1,86 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"todo-wails-go/internal/domain/query"
+)
+
+// expression compiles a query AST into a condition on the tasks table.
+// Nullable columns are guarded with IS NOT NULL so that negation selects the
+// same tasks as query.Match instead of dropping rows that compare as NULL.
+func (b *whereBuilder) expression(expr query.Expr) string {
+	switch e := expr.(type) {
+	case *query.And:
+		return b.join(e.Exprs, " AND ")
+	case *query.Or:
+		return b.join(e.Exprs, " OR ")
+	case *query.Not:
+		return "NOT " + b.expression(e.Expr)
+	case *query.Compare:
+		return b.compare(e)
+	case *query.Text:
+		search := b.dialect.searchQuery(e.Words)
+		if e.Phrase {
+			search = b.dialect.searchPhrase(e.Words)
+		}
+		return fmt.Sprintf(b.dialect.searchMatch, b.arg(search))
+	default:
+		return "FALSE"
+	}
+}
+
+// join compiles the operands of AND or OR in parentheses
+func (b *whereBuilder) join(exprs []query.Expr, op string) string {
+	parts := make([]string, len(exprs))
+	for i, expr := range exprs {
+		parts[i] = b.expression(expr)
+	}
+	return "(" + strings.Join(parts, op) + ")"
+}
+
+// compare compiles a field comparison
+func (b *whereBuilder) compare(e *query.Compare) string {
+	switch e.Field {
+	case query.FieldPriority:
+		return "priority " + string(e.Op) + " " + b.arg(e.Priority)
+	case query.FieldStatus:
+		return "status = " + b.arg(e.Status)
+	case query.FieldDue:
+		if e.None {
+			return "due_date IS NULL"
+		}
+		return "(due_date IS NOT NULL AND " + b.dayRange("due_date", e) + ")"
+	case query.FieldCreated:
+		return "(" + b.dayRange("created_at", e) + ")"
+	case query.FieldUpdated:
+		return "(" + b.dayRange("updated_at", e) + ")"
+	case query.FieldTag:
+		if e.None {
+			return "NOT EXISTS (SELECT 1 FROM task_tags tt WHERE tt.task_id = tasks.id)"
+		}
+		return "EXISTS (SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id AND LOWER(tg.name) = " +
+			b.arg(e.Name) + ")"
+	case query.FieldProject:
+		if e.None {
+			return "project_id IS NULL"
+		}
+		return "(project_id IS NOT NULL AND project_id = " + b.arg(e.Name) + ")"
+	default:
+		return "FALSE"
+	}
+}
+
+// dayRange compiles the bounds of a day-granular comparison on a column
+func (b *whereBuilder) dayRange(column string, e *query.Compare) string {
+	from, to := e.DayRange()
+	var bounds []string
+	if from != nil {
+		bounds = append(bounds, column+" >= "+b.arg(*from))
+	}
+	if to != nil {
+		bounds = append(bounds, column+" < "+b.arg(*to))
+	}
+	return strings.Join(bounds, " AND ")
+}
@@ -0,0 +1,266 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+)
+
+// reminderColumns is the column list read by scanReminder
+const reminderColumns = "id, task_id, offset_minutes, snoozed_until, fired_for, dismissed_at, created_at"
+
+// reminderOrder lists the reminders of a task earliest first
+const reminderOrder = " ORDER BY task_id, offset_minutes DESC"
+
+// scanReminder reads a reminder selected with reminderColumns
+func scanReminder(row rowScanner) (*models.Reminder, error) {
+	reminder := &models.Reminder{}
+	var snoozedUntil, firedFor, dismissedAt sql.NullTime
+	err := row.Scan(&reminder.ID, &reminder.TaskID, &reminder.Offset,
+		&snoozedUntil, &firedFor, &dismissedAt, &reminder.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if snoozedUntil.Valid {
+		reminder.SnoozedUntil = &snoozedUntil.Time
+	}
+	if firedFor.Valid {
+		reminder.FiredFor = &firedFor.Time
+	}
+	if dismissedAt.Valid {
+		reminder.DismissedAt = &dismissedAt.Time
+	}
+	return reminder, nil
+}
+
+// getReminder retrieves one reminder by ID
+func getReminder(ctx context.Context, db *sql.DB, dialect sqlDialect, id string) (*models.Reminder, error) {
+	b := &whereBuilder{dialect: dialect}
+	b.add("id = " + b.arg(id))
+	query := "SELECT " + reminderColumns + " FROM task_reminders" + b.where()
+
+	reminder, err := scanReminder(db.QueryRowContext(ctx, query, b.args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NotFound("reminder")
+		}
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// getReminders retrieves the reminders of one task, or of all tasks for ""
+func getReminders(ctx context.Context, db *sql.DB, dialect sqlDialect, taskID string) ([]*models.Reminder, error) {
+	b := &whereBuilder{dialect: dialect}
+	if taskID != "" {
+		b.add("task_id = " + b.arg(taskID))
+	}
+	query := "SELECT " + reminderColumns + " FROM task_reminders" + b.where() + reminderOrder
+
+	rows, err := db.QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := []*models.Reminder{}
+	for rows.Next() {
+		reminder, err := scanReminder(rows)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	return reminders, rows.Err()
+}
+
+// replaceReminders swaps the reminders of a task in one transaction
+func replaceReminders(ctx context.Context, db *sql.DB, dialect sqlDialect, taskID string, reminders []*models.Reminder) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	b := &whereBuilder{dialect: dialect}
+	b.add("task_id = " + b.arg(taskID))
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_reminders"+b.where(), b.args...); err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		b := &whereBuilder{dialect: dialect}
+		query := fmt.Sprintf("INSERT INTO task_reminders (%s) VALUES (%s, %s, %s, %s, %s, %s, %s)", reminderColumns,
+			b.arg(reminder.ID), b.arg(taskID), b.arg(reminder.Offset),
+			b.arg(reminder.SnoozedUntil), b.arg(reminder.FiredFor), b.arg(reminder.DismissedAt), b.arg(reminder.CreatedAt))
+		if _, err := tx.ExecContext(ctx, query, b.args...); err != nil {
+			return uniqueViolation(err, "duplicate reminder")
+		}
+	}
+
+	return tx.Commit()
+}
+
+// updateReminder saves the snooze and delivery state of a reminder
+func updateReminder(ctx context.Context, db *sql.DB, dialect sqlDialect, reminder *models.Reminder) error {
+	b := &whereBuilder{dialect: dialect}
+	query := fmt.Sprintf("UPDATE task_reminders SET snoozed_until = %s, fired_for = %s, dismissed_at = %s WHERE id = %s",
+		b.arg(reminder.SnoozedUntil), b.arg(reminder.FiredFor), b.arg(reminder.DismissedAt), b.arg(reminder.ID))
+	result, err := db.ExecContext(ctx, query, b.args...)
+	return affected(result, err, "reminder")
+}
+
+// GetReminder retrieves a reminder by ID
+func (r *PostgresRepository) GetReminder(ctx context.Context, id string) (*models.Reminder, error) {
+	return getReminder(ctx, r.db, postgresDialect, id)
+}
+
+// GetReminders retrieves the reminders of a task, earliest first
+func (r *PostgresRepository) GetReminders(ctx context.Context, taskID string) ([]*models.Reminder, error) {
+	return getReminders(ctx, r.db, postgresDialect, taskID)
+}
+
+// GetAllReminders retrieves the reminders of every task
+func (r *PostgresRepository) GetAllReminders(ctx context.Context) ([]*models.Reminder, error) {
+	return getReminders(ctx, r.db, postgresDialect, "")
+}
+
+// ReplaceReminders replaces the reminders of a task
+func (r *PostgresRepository) ReplaceReminders(ctx context.Context, taskID string, reminders []*models.Reminder) error {
+	return replaceReminders(ctx, r.db, postgresDialect, taskID, reminders)
+}
+
+// UpdateReminder saves the state of a reminder
+func (r *PostgresRepository) UpdateReminder(ctx context.Context, reminder *models.Reminder) error {
+	return updateReminder(ctx, r.db, postgresDialect, reminder)
+}
+
+// GetReminder retrieves a reminder by ID
+func (r *SQLiteRepository) GetReminder(ctx context.Context, id string) (*models.Reminder, error) {
+	return getReminder(ctx, r.db, sqliteDialect, id)
+}
+
+// GetReminders retrieves the reminders of a task, earliest first
+func (r *SQLiteRepository) GetReminders(ctx context.Context, taskID string) ([]*models.Reminder, error) {
+	return getReminders(ctx, r.db, sqliteDialect, taskID)
+}
+
+// GetAllReminders retrieves the reminders of every task
+func (r *SQLiteRepository) GetAllReminders(ctx context.Context) ([]*models.Reminder, error) {
+	return getReminders(ctx, r.db, sqliteDialect, "")
+}
+
+// ReplaceReminders replaces the reminders of a task
+func (r *SQLiteRepository) ReplaceReminders(ctx context.Context, taskID string, reminders []*models.Reminder) error {
+	return replaceReminders(ctx, r.db, sqliteDialect, taskID, reminders)
+}
+
+// UpdateReminder saves the state of a reminder
+func (r *SQLiteRepository) UpdateReminder(ctx context.Context, reminder *models.Reminder) error {
+	return updateReminder(ctx, r.db, sqliteDialect, reminder)
+}
+
+// GetReminder retrieves a reminder by ID
+func (r *MemoryRepository) GetReminder(ctx context.Context, id string) (*models.Reminder, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	reminder, exists := r.reminders[id]
+	if !exists {
+		return nil, domain.NotFound("reminder")
+	}
+	reminderCopy := *reminder
+	return &reminderCopy, nil
+}
+
+// GetReminders retrieves the reminders of a task, earliest first
+func (r *MemoryRepository) GetReminders(ctx context.Context, taskID string) ([]*models.Reminder, error) {
+	return r.listReminders(func(reminder *models.Reminder) bool {
+		return reminder.TaskID == taskID
+	}), nil
+}
+
+// GetAllReminders retrieves the reminders of every task
+func (r *MemoryRepository) GetAllReminders(ctx context.Context) ([]*models.Reminder, error) {
+	return r.listReminders(func(*models.Reminder) bool { return true }), nil
+}
+
+// listReminders copies the matching reminders in the order of the SQL repositories
+func (r *MemoryRepository) listReminders(match func(*models.Reminder) bool) []*models.Reminder {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	reminders := []*models.Reminder{}
+	for _, reminder := range r.reminders {
+		if match(reminder) {
+			reminderCopy := *reminder
+			reminders = append(reminders, &reminderCopy)
+		}
+	}
+
+	sort.Slice(reminders, func(i, j int) bool {
+		if reminders[i].TaskID != reminders[j].TaskID {
+			return reminders[i].TaskID < reminders[j].TaskID
+		}
+		return reminders[i].Offset > reminders[j].Offset
+	})
+	return reminders
+}
+
+// ReplaceReminders replaces the reminders of a task
+func (r *MemoryRepository) ReplaceReminders(ctx context.Context, taskID string, reminders []*models.Reminder) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Mirror the foreign key and the unique offset index of the SQL schema
+	if _, exists := r.tasks[taskID]; !exists {
+		return domain.NotFound("task")
+	}
+	offsets := make(map[int]bool)
+	for _, reminder := range reminders {
+		if offsets[reminder.Offset] {
+			return domain.Conflict("duplicate reminder")
+		}
+		offsets[reminder.Offset] = true
+	}
+
+	r.removeReminders(taskID)
+	for _, reminder := range reminders {
+		reminderCopy := *reminder
+		reminderCopy.TaskID = taskID
+		r.reminders[reminder.ID] = &reminderCopy
+	}
+	return nil
+}
+
+// UpdateReminder saves the state of a reminder
+func (r *MemoryRepository) UpdateReminder(ctx context.Context, reminder *models.Reminder) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored, exists := r.reminders[reminder.ID]
+	if !exists {
+		return domain.NotFound("reminder")
+	}
+	reminderCopy := *stored
+	reminderCopy.SnoozedUntil = reminder.SnoozedUntil
+	reminderCopy.FiredFor = reminder.FiredFor
+	reminderCopy.DismissedAt = reminder.DismissedAt
+	r.reminders[reminder.ID] = &reminderCopy
+	return nil
+}
+
+// removeReminders deletes the reminders of a task; the caller holds the lock
+func (r *MemoryRepository) removeReminders(taskID string) {
+	for id, reminder := range r.reminders {
+		if reminder.TaskID == taskID {
+			delete(r.reminders, id)
+		}
+	}
+}
@@ -0,0 +1,571 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/adapter/db/migrations"
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteRepository implements TaskRepository interface on an embedded SQLite file
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// DefaultSQLitePath returns the database file in the user's config directory,
+// creating the directory if needed
+func DefaultSQLitePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+
+	dir := filepath.Join(configDir, "todo-wails-go")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	return filepath.Join(dir, "todo.db"), nil
+}
+
+// SQLiteDSN returns the connection string for a database file with foreign keys enabled
+func SQLiteDSN(path string) string {
+	return "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+}
+
+// NewSQLiteRepository creates a new SQLite repository stored at path
+func NewSQLiteRepository(path string) (ports.TaskRepository, error) {
+	db, err := sql.Open("sqlite", SQLiteDSN(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Test connection
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	// Bring the schema up to date
+	if err := migrate(db, migrations.SQLite); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteRepository{db: db}, nil
+}
+
+// sqliteValue stores timestamps in UTC so that their text form sorts and compares chronologically
+func sqliteValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case time.Time:
+		return t.UTC()
+	case *time.Time:
+		if t == nil {
+			return nil
+		}
+		return t.UTC()
+	}
+	return v
+}
+
+// sqliteArgs applies sqliteValue to every argument
+func sqliteArgs(args ...interface{}) []interface{} {
+	for i, arg := range args {
+		args[i] = sqliteValue(arg)
+	}
+	return args
+}
+
+// sqliteInList returns "(?, ?, ...)" for n arguments
+func sqliteInList(n int) string {
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", n), ", ") + ")"
+}
+
+// Create creates a new task
+func (r *SQLiteRepository) Create(ctx context.Context, task *models.Task) error {
+	query := "INSERT INTO tasks (" + taskColumns + ") VALUES " + sqliteInList(15)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, query, sqliteArgs(
+		task.ID, task.Title, task.Description, task.Priority, task.Status,
+		task.DueDate, task.ParentID, task.ProjectID, recurrenceRule(task), task.SeriesID, task.Occurrence,
+		task.Version, task.CreatedAt, task.UpdatedAt, task.DeletedAt)...)
+	if err != nil {
+		return err
+	}
+
+	if err := r.saveTaskTags(ctx, tx, task); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a task by ID
+func (r *SQLiteRepository) GetByID(ctx context.Context, id string) (*models.Task, error) {
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = ?"
+
+	task, err := scanTask(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NotFound("task")
+		}
+		return nil, err
+	}
+
+	if err := r.loadTags(ctx, []*models.Task{task}); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// GetAll retrieves all tasks with optional filtering and sorting, matching PostgresRepository.GetAll
+func (r *SQLiteRepository) GetAll(ctx context.Context, filter *models.FilterOptions) ([]*models.Task, error) {
+	where, err := buildTaskFilter(filter, sqliteDialect)
+	if err != nil {
+		return nil, err
+	}
+	keys, cursor, err := taskPage(filter)
+	if err != nil {
+		return nil, err
+	}
+	if cursor != nil {
+		where.keyset(cursor, keys)
+	}
+	query := "SELECT " + taskColumns + " FROM tasks" + where.where() + where.orderBy(keys) + taskLimit(filter)
+
+	rows, err := r.db.QueryContext(ctx, query, where.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTasks(ctx, rows)
+}
+
+// Count returns the number of tasks matching filter, ignoring its page
+func (r *SQLiteRepository) Count(ctx context.Context, filter *models.FilterOptions) (int, error) {
+	where, err := buildTaskFilter(filter, sqliteDialect)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM tasks"+where.where(), where.args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Search retrieves the tasks matching filter.Query, most relevant first
+func (r *SQLiteRepository) Search(ctx context.Context, filter *models.FilterOptions) ([]*models.SearchResult, error) {
+	query, args, err := buildTaskSearch(filter, sqliteDialect)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*models.SearchResult
+	var tasks []*models.Task
+	for rows.Next() {
+		result := &models.SearchResult{}
+		result.Task, err = scanTask(rows, &result.Rank)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+		tasks = append(tasks, result.Task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadTags(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetDescendants retrieves every task below the given task, parents before children
+func (r *SQLiteRepository) GetDescendants(ctx context.Context, id string) ([]*models.Task, error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT ` + taskColumns + `, 1 AS depth FROM tasks WHERE parent_id = ?
+			UNION ALL
+			SELECT ` + qualifiedTaskColumns("t") + `, s.depth + 1
+			FROM tasks t JOIN subtree s ON t.parent_id = s.id
+		)
+		SELECT ` + taskColumns + ` FROM subtree ORDER BY depth, created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTasks(ctx, rows)
+}
+
+// scanTasks reads all rows selected with taskColumns and loads their tags
+func (r *SQLiteRepository) scanTasks(ctx context.Context, rows *sql.Rows) ([]*models.Task, error) {
+	var tasks []*models.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadTags(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// Update updates an existing task
+func (r *SQLiteRepository) Update(ctx context.Context, task *models.Task) error {
+	query := `
+		UPDATE tasks
+		SET title = ?, description = ?, priority = ?, status = ?, due_date = ?, parent_id = ?,
+			project_id = ?, recurrence = ?, series_id = ?, occurrence = ?, updated_at = ?, deleted_at = ?,
+			version = version + 1
+		WHERE id = ? AND version = ?
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, sqliteArgs(
+		task.Title, task.Description, task.Priority, task.Status,
+		task.DueDate, task.ParentID, task.ProjectID, recurrenceRule(task), task.SeriesID, task.Occurrence,
+		task.UpdatedAt, task.DeletedAt, task.ID, task.Version)...)
+	if err := affected(result, err, "task"); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			tx.Rollback()
+			return versionConflict(ctx, r, task.ID)
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = ?", task.ID); err != nil {
+		return err
+	}
+
+	if err := r.saveTaskTags(ctx, tx, task); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	task.Version++
+	return nil
+}
+
+// Patch writes only the given fields of a task, with the same version check as Update
+func (r *SQLiteRepository) Patch(ctx context.Context, task *models.Task, fields []models.TaskField) error {
+	query, args, tags, err := buildTaskPatch(task, fields, sqliteDialect)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err := affected(result, err, "task"); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			tx.Rollback()
+			return versionConflict(ctx, r, task.ID)
+		}
+		return err
+	}
+
+	if tags {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = ?", task.ID); err != nil {
+			return err
+		}
+		if err := r.saveTaskTags(ctx, tx, task); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	task.Version++
+	return nil
+}
+
+// Delete deletes a task by ID
+func (r *SQLiteRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM tasks WHERE id = ?", id)
+	return affected(result, err, "task")
+}
+
+// PurgeTrash permanently deletes the tasks trashed at or before the given time
+func (r *SQLiteRepository) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	query := "DELETE FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at <= ?"
+	result, err := r.db.ExecContext(ctx, query, sqliteValue(before))
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// saveTaskTags inserts the task-to-tag links of a task
+func (r *SQLiteRepository) saveTaskTags(ctx context.Context, tx *sql.Tx, task *models.Task) error {
+	for _, tag := range task.Tags {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO task_tags (task_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			task.ID, tag.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadTags fills the Tags field of the given tasks
+func (r *SQLiteRepository) loadTags(ctx context.Context, tasks []*models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.Task, len(tasks))
+	args := make([]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		task.Tags = []models.Tag{}
+		byID[task.ID] = task
+		args = append(args, task.ID)
+	}
+
+	query := `
+		SELECT tt.task_id, tg.id, tg.name, tg.color, tg.created_at
+		FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+		WHERE tt.task_id IN ` + sqliteInList(len(args)) + `
+		ORDER BY LOWER(tg.name)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID string
+		var tag models.Tag
+		if err := rows.Scan(&taskID, &tag.ID, &tag.Name, &tag.Color, &tag.CreatedAt); err != nil {
+			return err
+		}
+		if task, ok := byID[taskID]; ok {
+			task.Tags = append(task.Tags, tag)
+		}
+	}
+
+	return rows.Err()
+}
+
+// CreateTag creates a new tag
+func (r *SQLiteRepository) CreateTag(ctx context.Context, tag *models.Tag) error {
+	query := "INSERT INTO tags (id, name, color, created_at) VALUES (?, ?, ?, ?)"
+	_, err := r.db.ExecContext(ctx, query, sqliteArgs(tag.ID, tag.Name, tag.Color, tag.CreatedAt)...)
+	return uniqueViolation(err, "tag already exists")
+}
+
+// GetTagByID retrieves a tag by ID
+func (r *SQLiteRepository) GetTagByID(ctx context.Context, id string) (*models.Tag, error) {
+	return r.getTag(ctx, "SELECT id, name, color, created_at FROM tags WHERE id = ?", id)
+}
+
+// GetTagByName retrieves a tag by its case-insensitive name
+func (r *SQLiteRepository) GetTagByName(ctx context.Context, name string) (*models.Tag, error) {
+	return r.getTag(ctx, "SELECT id, name, color, created_at FROM tags WHERE LOWER(name) = LOWER(?)", name)
+}
+
+// getTag runs a single-tag query
+func (r *SQLiteRepository) getTag(ctx context.Context, query string, arg interface{}) (*models.Tag, error) {
+	tag := &models.Tag{}
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(&tag.ID, &tag.Name, &tag.Color, &tag.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NotFound("tag")
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
+// GetTags retrieves all tags sorted by name
+func (r *SQLiteRepository) GetTags(ctx context.Context) ([]*models.Tag, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, color, created_at FROM tags ORDER BY LOWER(name)")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []*models.Tag{}
+	for rows.Next() {
+		tag := &models.Tag{}
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Color, &tag.CreatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// UpdateTag updates an existing tag
+func (r *SQLiteRepository) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE tags SET name = ?, color = ? WHERE id = ?", tag.Name, tag.Color, tag.ID)
+	return uniqueViolation(affected(result, err, "tag"), "tag already exists")
+}
+
+// DeleteTag deletes a tag by ID; its task links are removed by the foreign key cascade
+func (r *SQLiteRepository) DeleteTag(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM tags WHERE id = ?", id)
+	return affected(result, err, "tag")
+}
+
+// CreateProject creates a new project
+func (r *SQLiteRepository) CreateProject(ctx context.Context, project *models.Project) error {
+	query := "INSERT INTO projects (" + projectColumns + ") VALUES " + sqliteInList(7)
+	_, err := r.db.ExecContext(ctx, query, sqliteArgs(
+		project.ID, project.Name, project.Color, project.Archived,
+		project.SortOrder, project.CreatedAt, project.UpdatedAt)...)
+	return err
+}
+
+// GetProjectByID retrieves a project by ID
+func (r *SQLiteRepository) GetProjectByID(ctx context.Context, id string) (*models.Project, error) {
+	query := "SELECT " + projectColumns + " FROM projects WHERE id = ?"
+
+	project, err := scanProject(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NotFound("project")
+		}
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// GetProjects retrieves projects by sort order, optionally including archived ones
+func (r *SQLiteRepository) GetProjects(ctx context.Context, includeArchived bool) ([]*models.Project, error) {
+	query := "SELECT " + projectColumns + " FROM projects"
+	if !includeArchived {
+		query += " WHERE NOT archived"
+	}
+	query += " ORDER BY sort_order, LOWER(name)"
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects := []*models.Project{}
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+// UpdateProject updates an existing project
+func (r *SQLiteRepository) UpdateProject(ctx context.Context, project *models.Project) error {
+	query := `
+		UPDATE projects
+		SET name = ?, color = ?, archived = ?, sort_order = ?, updated_at = ?
+		WHERE id = ?
+	`
+	result, err := r.db.ExecContext(ctx, query, sqliteArgs(
+		project.Name, project.Color, project.Archived, project.SortOrder, project.UpdatedAt, project.ID)...)
+	return affected(result, err, "project")
+}
+
+// DeleteProject deletes a project; its tasks are kept without a project by the foreign key
+func (r *SQLiteRepository) DeleteProject(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM projects WHERE id = ?", id)
+	return affected(result, err, "project")
+}
+
+// GetProjectTaskCounts counts tasks per project ID, using "" for tasks without a project
+func (r *SQLiteRepository) GetProjectTaskCounts(ctx context.Context) (map[string]models.TaskCounts, error) {
+	query := `
+		SELECT COALESCE(project_id, ''), COUNT(*),
+			SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), SUM(CASE WHEN status = ? THEN 1 ELSE 0 END)
+		FROM tasks WHERE deleted_at IS NULL GROUP BY project_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, models.StatusActive, models.StatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]models.TaskCounts)
+	for rows.Next() {
+		var projectID string
+		var c models.TaskCounts
+		if err := rows.Scan(&projectID, &c.Total, &c.Active, &c.Completed); err != nil {
+			return nil, err
+		}
+		counts[projectID] = c
+	}
+
+	return counts, rows.Err()
+}
+
+// Close closes the database connection
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
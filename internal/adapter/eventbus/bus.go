@@ -0,0 +1,77 @@
+// Package eventbus delivers domain events to subscribers within the process.
+package eventbus
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// Handler receives the events published on a bus
+type Handler func(ctx context.Context, event models.Event)
+
+// subscription is one registered handler
+type subscription struct {
+	id      int
+	handler Handler
+}
+
+// Bus is an in-process event bus. Events are delivered synchronously, in the
+// order they were published, to the handlers in the order they subscribed.
+type Bus struct {
+	mutex         sync.RWMutex
+	subscriptions []subscription
+	lastID        int
+}
+
+// New creates an empty event bus
+func New() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers a handler for every event and returns a function that
+// removes it again
+func (b *Bus) Subscribe(handler Handler) (unsubscribe func()) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.lastID++
+	id := b.lastID
+	b.subscriptions = append(b.subscriptions, subscription{id: id, handler: handler})
+
+	return func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+
+		for i, sub := range b.subscriptions {
+			if sub.id == id {
+				b.subscriptions = append(b.subscriptions[:i:i], b.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish delivers an event to every handler. The change has already been
+// saved, so a failing handler is logged and does not stop the others.
+func (b *Bus) Publish(ctx context.Context, event models.Event) {
+	b.mutex.RLock()
+	subscriptions := b.subscriptions
+	b.mutex.RUnlock()
+
+	for _, sub := range subscriptions {
+		deliver(ctx, sub.handler, event)
+	}
+}
+
+// deliver calls one handler, recovering from a panic in it
+func deliver(ctx context.Context, handler Handler, event models.Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Warning: Event handler for %s failed: %v", event.Type, r)
+		}
+	}()
+	handler(ctx, event)
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"encoding/json"
+
+	"todo-wails-go/internal/domain"
+)
+
+// Error is returned by every handler method. Its message is a JSON payload
+// such as {"code":"not_found","message":"task not found"} so the frontend can
+// branch on the stable code instead of parsing text.
+type Error struct {
+	Code     string      `json:"code"`
+	Message  string      `json:"message"`
+	Field    string      `json:"field,omitempty"`
+	Position int         `json:"position,omitempty"` // 1-based position of a syntax error in the field
+	Current  interface{} `json:"current,omitempty"`  // latest server state on a version conflict
+	err      error
+}
+
+// Error implements error by serializing the payload
+func (e *Error) Error() string {
+	payload, _ := json.Marshal(e)
+	return string(payload)
+}
+
+// Unwrap exposes the original error to errors.Is and errors.As
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// encodeError wraps err in the structured error payload
+func encodeError(err error) error {
+	return &Error{
+		Code:     domain.Code(err),
+		Message:  err.Error(),
+		Field:    domain.Field(err),
+		Position: domain.Position(err),
+		Current:  domain.Current(err),
+		err:      err,
+	}
+}
+
+// invalidFormat reports a request that is not valid JSON for its type
+func invalidFormat(what string, err error) error {
+	return encodeError(domain.Invalid("", "invalid %s format: %v", what, err))
+}
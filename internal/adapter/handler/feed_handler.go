@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/usecase"
+)
+
+// FeedHandler handles calendar feed requests
+type FeedHandler struct {
+	useCase *usecase.FeedUseCase
+}
+
+// NewFeedHandler creates a new feed handler
+func NewFeedHandler(useCase *usecase.FeedUseCase) *FeedHandler {
+	return &FeedHandler{useCase: useCase}
+}
+
+// CreateFeed creates a new feed
+func (h *FeedHandler) CreateFeed(ctx context.Context, reqJSON string) (string, error) {
+	var req models.CreateFeedRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return "", invalidFormat("request", err)
+	}
+
+	feed, err := h.useCase.CreateFeed(ctx, &req)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(feed)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// GetFeeds retrieves all feeds
+func (h *FeedHandler) GetFeeds(ctx context.Context) (string, error) {
+	feeds, err := h.useCase.GetFeeds(ctx)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(feeds)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// DeleteFeed deletes a feed by ID
+func (h *FeedHandler) DeleteFeed(ctx context.Context, id string) error {
+	if err := h.useCase.DeleteFeed(ctx, id); err != nil {
+		return encodeError(err)
+	}
+	return nil
+}
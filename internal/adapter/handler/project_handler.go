@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/usecase"
+)
+
+// ProjectHandler handles project-related requests
+type ProjectHandler struct {
+	useCase *usecase.ProjectUseCase
+}
+
+// NewProjectHandler creates a new project handler
+func NewProjectHandler(useCase *usecase.ProjectUseCase) *ProjectHandler {
+	return &ProjectHandler{useCase: useCase}
+}
+
+// CreateProject creates a new project
+func (h *ProjectHandler) CreateProject(ctx context.Context, reqJSON string) (string, error) {
+	var req models.CreateProjectRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return "", invalidFormat("request", err)
+	}
+
+	project, err := h.useCase.CreateProject(ctx, &req)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(project)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// GetProject retrieves a project by ID
+func (h *ProjectHandler) GetProject(ctx context.Context, id string) (string, error) {
+	project, err := h.useCase.GetProject(ctx, id)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(project)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// GetProjects retrieves all projects with their task counts
+func (h *ProjectHandler) GetProjects(ctx context.Context, includeArchived bool) (string, error) {
+	projects, err := h.useCase.GetProjects(ctx, includeArchived)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(projects)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// UpdateProject updates an existing project
+func (h *ProjectHandler) UpdateProject(ctx context.Context, reqJSON string) (string, error) {
+	var req models.UpdateProjectRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return "", invalidFormat("request", err)
+	}
+
+	project, err := h.useCase.UpdateProject(ctx, &req)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(project)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// DeleteProject deletes a project by ID
+func (h *ProjectHandler) DeleteProject(ctx context.Context, id string) error {
+	if err := h.useCase.DeleteProject(ctx, id); err != nil {
+		return encodeError(err)
+	}
+	return nil
+}
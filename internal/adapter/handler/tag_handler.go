@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// CreateTag creates a new tag
+func (h *TaskHandler) CreateTag(ctx context.Context, reqJSON string) (string, error) {
+	var req models.CreateTagRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return "", invalidFormat("request", err)
+	}
+
+	tag, err := h.useCase.CreateTag(ctx, &req)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(tag)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// GetTags retrieves all tags
+func (h *TaskHandler) GetTags(ctx context.Context) (string, error) {
+	tags, err := h.useCase.GetTags(ctx)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(tags)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// UpdateTag renames or recolors a tag
+func (h *TaskHandler) UpdateTag(ctx context.Context, reqJSON string) (string, error) {
+	var req models.UpdateTagRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return "", invalidFormat("request", err)
+	}
+
+	tag, err := h.useCase.UpdateTag(ctx, &req)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(tag)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// DeleteTag deletes a tag by ID
+func (h *TaskHandler) DeleteTag(ctx context.Context, id string) error {
+	if err := h.useCase.DeleteTag(ctx, id); err != nil {
+		return encodeError(err)
+	}
+	return nil
+}
+
+// AddTagToTask attaches a tag to a task by name
+func (h *TaskHandler) AddTagToTask(ctx context.Context, taskID, tagName string) (string, error) {
+	task, err := h.useCase.AddTagToTask(ctx, taskID, tagName)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(task)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// RemoveTagFromTask detaches a tag from a task
+func (h *TaskHandler) RemoveTagFromTask(ctx context.Context, taskID, tagID string) (string, error) {
+	task, err := h.useCase.RemoveTagFromTask(ctx, taskID, tagID)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(task)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// GetTasksByTags retrieves tasks carrying at least one of the given tags
+func (h *TaskHandler) GetTasksByTags(ctx context.Context, tags []string) (string, error) {
+	tasks, err := h.useCase.GetTasksByTags(ctx, tags)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(tasks)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
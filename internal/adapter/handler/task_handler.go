@@ -4,7 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
+	"todo-wails-go/internal/adapter/backup"
+	"todo-wails-go/internal/adapter/ical"
+	"todo-wails-go/internal/domain"
 	"todo-wails-go/internal/domain/models"
 	"todo-wails-go/internal/usecase"
 )
@@ -23,17 +28,17 @@ func NewTaskHandler(useCase *usecase.TaskUseCase) *TaskHandler {
 func (h *TaskHandler) CreateTask(ctx context.Context, reqJSON string) (string, error) {
 	var req models.CreateTaskRequest
 	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
-		return "", fmt.Errorf("invalid request format: %w", err)
+		return "", invalidFormat("request", err)
 	}
 
 	task, err := h.useCase.CreateTask(ctx, &req)
 	if err != nil {
-		return "", err
+		return "", encodeError(err)
 	}
 
 	result, err := json.Marshal(task)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal response: %w", err)
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
 	}
 
 	return string(result), nil
@@ -43,35 +48,60 @@ func (h *TaskHandler) CreateTask(ctx context.Context, reqJSON string) (string, e
 func (h *TaskHandler) GetTask(ctx context.Context, id string) (string, error) {
 	task, err := h.useCase.GetTask(ctx, id)
 	if err != nil {
-		return "", err
+		return "", encodeError(err)
 	}
 
 	result, err := json.Marshal(task)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal response: %w", err)
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
 	}
 
 	return string(result), nil
 }
 
-// GetTasks retrieves all tasks with optional filtering
+// GetTasks retrieves a page of tasks with optional filtering as
+// {"items": [...], "nextCursor": "...", "total": n}
 func (h *TaskHandler) GetTasks(ctx context.Context, filterJSON string) (string, error) {
 	var filter *models.FilterOptions
 	if filterJSON != "" {
 		filter = &models.FilterOptions{}
 		if err := json.Unmarshal([]byte(filterJSON), filter); err != nil {
-			return "", fmt.Errorf("invalid filter format: %w", err)
+			return "", invalidFormat("filter", err)
 		}
 	}
 
-	tasks, err := h.useCase.GetTasks(ctx, filter)
+	page, err := h.useCase.ListTasks(ctx, filter)
 	if err != nil {
-		return "", err
+		return "", encodeError(err)
 	}
 
-	result, err := json.Marshal(tasks)
+	result, err := json.Marshal(page)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// SearchTasks runs a full-text search; the filter must contain a query
+func (h *TaskHandler) SearchTasks(ctx context.Context, filterJSON string) (string, error) {
+	filter := &models.FilterOptions{}
+	if err := json.Unmarshal([]byte(filterJSON), filter); err != nil {
+		return "", invalidFormat("filter", err)
+	}
+
+	results, err := h.useCase.SearchTasks(ctx, filter)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal response: %w", err)
+		return "", encodeError(err)
+	}
+
+	if results == nil {
+		results = []*models.SearchResult{}
+	}
+
+	result, err := json.Marshal(results)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
 	}
 
 	return string(result), nil
@@ -81,17 +111,37 @@ func (h *TaskHandler) GetTasks(ctx context.Context, filterJSON string) (string,
 func (h *TaskHandler) UpdateTask(ctx context.Context, reqJSON string) (string, error) {
 	var req models.UpdateTaskRequest
 	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
-		return "", fmt.Errorf("invalid request format: %w", err)
+		return "", invalidFormat("request", err)
 	}
 
 	task, err := h.useCase.UpdateTask(ctx, &req)
 	if err != nil {
-		return "", err
+		return "", encodeError(err)
 	}
 
 	result, err := json.Marshal(task)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal response: %w", err)
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// PatchTask changes only the fields present in the request
+func (h *TaskHandler) PatchTask(ctx context.Context, reqJSON string) (string, error) {
+	var req models.PatchTaskRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return "", invalidFormat("request", err)
+	}
+
+	task, err := h.useCase.PatchTask(ctx, &req)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(task)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
 	}
 
 	return string(result), nil
@@ -99,19 +149,22 @@ func (h *TaskHandler) UpdateTask(ctx context.Context, reqJSON string) (string, e
 
 // DeleteTask deletes a task by ID
 func (h *TaskHandler) DeleteTask(ctx context.Context, id string) error {
-	return h.useCase.DeleteTask(ctx, id)
+	if err := h.useCase.DeleteTask(ctx, id); err != nil {
+		return encodeError(err)
+	}
+	return nil
 }
 
 // ToggleTaskStatus toggles the completion status of a task
 func (h *TaskHandler) ToggleTaskStatus(ctx context.Context, id string) (string, error) {
 	task, err := h.useCase.ToggleTaskStatus(ctx, id)
 	if err != nil {
-		return "", err
+		return "", encodeError(err)
 	}
 
 	result, err := json.Marshal(task)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal response: %w", err)
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
 	}
 
 	return string(result), nil
@@ -121,12 +174,12 @@ func (h *TaskHandler) ToggleTaskStatus(ctx context.Context, id string) (string,
 func (h *TaskHandler) GetTasksByStatus(ctx context.Context, status int) (string, error) {
 	tasks, err := h.useCase.GetTasksByStatus(ctx, models.Status(status))
 	if err != nil {
-		return "", err
+		return "", encodeError(err)
 	}
 
 	result, err := json.Marshal(tasks)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal response: %w", err)
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
 	}
 
 	return string(result), nil
@@ -136,12 +189,12 @@ func (h *TaskHandler) GetTasksByStatus(ctx context.Context, status int) (string,
 func (h *TaskHandler) GetTasksByPriority(ctx context.Context, priority int) (string, error) {
 	tasks, err := h.useCase.GetTasksByPriority(ctx, models.Priority(priority))
 	if err != nil {
-		return "", err
+		return "", encodeError(err)
 	}
 
 	result, err := json.Marshal(tasks)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal response: %w", err)
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
 	}
 
 	return string(result), nil
@@ -151,12 +204,480 @@ func (h *TaskHandler) GetTasksByPriority(ctx context.Context, priority int) (str
 func (h *TaskHandler) GetOverdueTasks(ctx context.Context) (string, error) {
 	tasks, err := h.useCase.GetOverdueTasks(ctx)
 	if err != nil {
-		return "", err
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(tasks)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// GetTasksDueToday retrieves active tasks due today
+func (h *TaskHandler) GetTasksDueToday(ctx context.Context) (string, error) {
+	tasks, err := h.useCase.GetTasksDueToday(ctx)
+	if err != nil {
+		return "", encodeError(err)
 	}
 
 	result, err := json.Marshal(tasks)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal response: %w", err)
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// GetTasksDueThisWeek retrieves active tasks due from today until Sunday
+func (h *TaskHandler) GetTasksDueThisWeek(ctx context.Context) (string, error) {
+	tasks, err := h.useCase.GetTasksDueThisWeek(ctx)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(tasks)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// GetTasksByDateRange retrieves tasks due in [from, to). Both bounds are
+// RFC 3339 timestamps or YYYY-MM-DD dates meaning local midnight.
+func (h *TaskHandler) GetTasksByDateRange(ctx context.Context, from, to string) (string, error) {
+	fromTime, err := parseTime("from", from)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	toTime, err := parseTime("to", to)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	if !fromTime.Before(toTime) {
+		return "", encodeError(domain.Invalid("to", "to must be after from"))
+	}
+
+	tasks, err := h.useCase.GetTasksByDateRange(ctx, fromTime, toTime)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(tasks)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// parseTime parses an RFC 3339 timestamp or a YYYY-MM-DD date in local time
+func parseTime(field, value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, domain.Invalid(field, "invalid %s %q, expected RFC 3339 or YYYY-MM-DD", field, value)
+}
+
+// GetChildren retrieves the direct subtasks of a task
+func (h *TaskHandler) GetChildren(ctx context.Context, id string) (string, error) {
+	tasks, err := h.useCase.GetChildren(ctx, id)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(tasks)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// GetSubtree retrieves a task with all of its subtasks
+func (h *TaskHandler) GetSubtree(ctx context.Context, id string) (string, error) {
+	node, err := h.useCase.GetSubtree(ctx, id)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(node)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// MoveTask moves a task under another parent or to the top level
+func (h *TaskHandler) MoveTask(ctx context.Context, reqJSON string) (string, error) {
+	var req models.MoveTaskRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return "", invalidFormat("request", err)
+	}
+
+	task, err := h.useCase.MoveTask(ctx, &req)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(task)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// SetRecurrence sets or clears the recurrence rule of a task
+func (h *TaskHandler) SetRecurrence(ctx context.Context, reqJSON string) (string, error) {
+	var req models.SetRecurrenceRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return "", invalidFormat("request", err)
+	}
+
+	task, err := h.useCase.SetRecurrence(ctx, &req)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(task)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// GetTasksByProject retrieves the tasks of a project
+func (h *TaskHandler) GetTasksByProject(ctx context.Context, projectID string) (string, error) {
+	tasks, err := h.useCase.GetTasksByProject(ctx, projectID)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(tasks)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// ListTrash retrieves the tasks in the trash
+func (h *TaskHandler) ListTrash(ctx context.Context) (string, error) {
+	tasks, err := h.useCase.ListTrash(ctx)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(tasks)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// RestoreTask takes a task out of the trash
+func (h *TaskHandler) RestoreTask(ctx context.Context, id string) (string, error) {
+	task, err := h.useCase.RestoreTask(ctx, id)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(task)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// EmptyTrash permanently deletes every task in the trash and returns how many were deleted
+func (h *TaskHandler) EmptyTrash(ctx context.Context) (int, error) {
+	n, err := h.useCase.EmptyTrash(ctx)
+	if err != nil {
+		return 0, encodeError(err)
+	}
+	return n, nil
+}
+
+// Undo reverts the most recent task mutation and returns its history entry
+func (h *TaskHandler) Undo(ctx context.Context) (string, error) {
+	entry, err := h.useCase.Undo(ctx)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(entry)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// Redo applies the most recently undone task mutation again
+func (h *TaskHandler) Redo(ctx context.Context) (string, error) {
+	entry, err := h.useCase.Redo(ctx)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(entry)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// History lists the task mutations that can be undone and redone
+func (h *TaskHandler) History(ctx context.Context) (string, error) {
+	history, err := h.useCase.History(ctx)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(history)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// GetTaskHistory returns the audit log of a task as a timeline, oldest change first
+func (h *TaskHandler) GetTaskHistory(ctx context.Context, id string) (string, error) {
+	events, err := h.useCase.GetTaskHistory(ctx, id)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	if events == nil {
+		events = []*models.TaskEvent{}
+	}
+
+	result, err := json.Marshal(events)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// ExportICS returns the tasks matching the filter as an iCalendar file of VTODOs
+func (h *TaskHandler) ExportICS(ctx context.Context, filterJSON string) (string, error) {
+	var filter *models.FilterOptions
+	if filterJSON != "" {
+		filter = &models.FilterOptions{}
+		if err := json.Unmarshal([]byte(filterJSON), filter); err != nil {
+			return "", invalidFormat("filter", err)
+		}
+	}
+
+	tasks, err := h.useCase.GetTasks(ctx, filter)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	var b strings.Builder
+	if err := ical.Encode(&b, tasks); err != nil {
+		return "", encodeError(fmt.Errorf("failed to encode calendar: %w", err))
+	}
+
+	return b.String(), nil
+}
+
+// ImportICS creates tasks from the VTODOs of an iCalendar file
+func (h *TaskHandler) ImportICS(ctx context.Context, data string) (string, error) {
+	tasks, err := ical.Decode(strings.NewReader(data))
+	if err != nil {
+		return "", invalidFormat("iCalendar", err)
+	}
+
+	report, err := h.useCase.ImportTasks(ctx, &models.Backup{Tasks: tasks}, nil)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(report)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// ExportTasks returns the tasks matching the filter, including the ones in
+// the trash, as a CSV or JSON Lines file with their projects and tags
+func (h *TaskHandler) ExportTasks(ctx context.Context, format, filterJSON string) (string, error) {
+	f := backup.Format(format)
+	if !f.Valid() {
+		return "", encodeError(domain.Invalid("format", "unknown format %q", format))
+	}
+
+	var filter *models.FilterOptions
+	if filterJSON != "" {
+		filter = &models.FilterOptions{}
+		if err := json.Unmarshal([]byte(filterJSON), filter); err != nil {
+			return "", invalidFormat("filter", err)
+		}
+	}
+
+	data, err := h.useCase.ExportTasks(ctx, filter)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	var b strings.Builder
+	if err := f.Encode(&b, data); err != nil {
+		return "", encodeError(fmt.Errorf("failed to encode tasks: %w", err))
+	}
+
+	return b.String(), nil
+}
+
+// ImportTasks imports the tasks of a CSV or JSON Lines file with the given
+// conflict strategy, or only reports what would change for a dry run
+func (h *TaskHandler) ImportTasks(ctx context.Context, format, data, optionsJSON string) (string, error) {
+	f := backup.Format(format)
+	if !f.Valid() {
+		return "", encodeError(domain.Invalid("format", "unknown format %q", format))
+	}
+
+	var opts models.ImportOptions
+	if optionsJSON != "" {
+		if err := json.Unmarshal([]byte(optionsJSON), &opts); err != nil {
+			return "", invalidFormat("options", err)
+		}
+	}
+
+	decoded, err := f.Decode(strings.NewReader(data))
+	if err != nil {
+		return "", invalidFormat(format, err)
+	}
+
+	report, err := h.useCase.ImportTasks(ctx, decoded, &opts)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(report)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// ExportTodoTxt returns the tasks matching the filter as a todo.txt file
+func (h *TaskHandler) ExportTodoTxt(ctx context.Context, filterJSON string) (string, error) {
+	var filter *models.FilterOptions
+	if filterJSON != "" {
+		filter = &models.FilterOptions{}
+		if err := json.Unmarshal([]byte(filterJSON), filter); err != nil {
+			return "", invalidFormat("filter", err)
+		}
+	}
+
+	var b strings.Builder
+	if err := h.useCase.ExportTodoTxt(ctx, &b, filter); err != nil {
+		return "", encodeError(err)
+	}
+
+	return b.String(), nil
+}
+
+// ImportTodoTxt creates tasks from the lines of a todo.txt file; a "+project"
+// refers to an existing project by name
+func (h *TaskHandler) ImportTodoTxt(ctx context.Context, data string) (string, error) {
+	report, err := h.useCase.ImportTodoTxt(ctx, strings.NewReader(data))
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(report)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// GetReminders returns the reminders of a task, earliest first
+func (h *TaskHandler) GetReminders(ctx context.Context, taskID string) (string, error) {
+	reminders, err := h.useCase.GetReminders(ctx, taskID)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(reminders)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// SetReminders replaces the reminders of a task
+func (h *TaskHandler) SetReminders(ctx context.Context, reqJSON string) (string, error) {
+	var req models.SetRemindersRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return "", invalidFormat("request", err)
+	}
+
+	reminders, err := h.useCase.SetReminders(ctx, &req)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(reminders)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// SnoozeReminder postpones a reminder by the requested number of minutes
+func (h *TaskHandler) SnoozeReminder(ctx context.Context, reqJSON string) (string, error) {
+	var req models.SnoozeReminderRequest
+	if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+		return "", invalidFormat("request", err)
+	}
+
+	reminder, err := h.useCase.SnoozeReminder(ctx, &req)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(reminder)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
+	}
+
+	return string(result), nil
+}
+
+// DismissReminder silences a reminder until the due date of its task moves
+func (h *TaskHandler) DismissReminder(ctx context.Context, id string) (string, error) {
+	reminder, err := h.useCase.DismissReminder(ctx, id)
+	if err != nil {
+		return "", encodeError(err)
+	}
+
+	result, err := json.Marshal(reminder)
+	if err != nil {
+		return "", encodeError(fmt.Errorf("failed to marshal response: %w", err))
 	}
 
 	return string(result), nil
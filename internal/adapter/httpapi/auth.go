@@ -0,0 +1,47 @@
+package httpapi
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// codeUnauthorized is the error code of a request without a valid API token
+const codeUnauthorized = "unauthorized"
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithToken makes every route except the calendar feeds require the header
+// "Authorization: Bearer <token>"; an empty token leaves the API open.
+// Calendar apps cannot send the header, so feeds rely on their own token.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// authorized reports whether a request may reach its route
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" || isFeedCalendar(r) {
+		return true
+	}
+	given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(given), []byte(s.token)) == 1
+}
+
+// isFeedCalendar reports whether a request is for GET /feeds/{token}/tasks.ics
+func isFeedCalendar(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		strings.HasPrefix(r.URL.Path, "/feeds/") &&
+		strings.HasSuffix(r.URL.Path, "/tasks.ics")
+}
+
+// writeUnauthorized rejects a request without a valid API token
+func writeUnauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="todo"`)
+	writeJSON(w, http.StatusUnauthorized, errorBody{
+		Code:    codeUnauthorized,
+		Message: "missing or invalid API token",
+	})
+}
@@ -0,0 +1,91 @@
+package httpapi
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"todo-wails-go/internal/adapter/backup"
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+)
+
+// maxImportBytes limits the size of an imported file, which can be a full backup
+const maxImportBytes = 64 << 20
+
+// exportTasks handles GET /export?format=csv|jsonl, with the filter
+// parameters of GET /tasks; the file also holds the matching tasks in the
+// trash and every project and tag. The format defaults to JSON Lines.
+func (s *Server) exportTasks(w http.ResponseWriter, r *http.Request) {
+	format, err := parseFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	filter, err := parseFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	data, err := s.useCase.ExportTasks(r.Context(), filter)
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	var body bytes.Buffer
+	if err := format.Encode(&body, data); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to encode tasks: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="tasks.%s"`, format))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+// importTasks handles POST /import?format=csv|jsonl&strategy=...&dryRun=true
+// with the file as the body and responds with the import report
+func (s *Server) importTasks(w http.ResponseWriter, r *http.Request) {
+	format, err := parseFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	query := r.URL.Query()
+	opts := &models.ImportOptions{
+		Strategy: models.ConflictStrategy(query.Get("strategy")),
+		DryRun:   query.Get("dryRun") == "true",
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+	data, err := format.Decode(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, domain.Invalid("", "invalid %s format: %v", format, err))
+		return
+	}
+
+	report, err := s.useCase.ImportTasks(r.Context(), data, opts)
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// parseFormat reads the format query parameter
+func parseFormat(r *http.Request) (backup.Format, error) {
+	format := backup.Format(r.URL.Query().Get("format"))
+	if format == "" {
+		return backup.JSONL, nil
+	}
+	if !format.Valid() {
+		return "", domain.Invalid("format", "unknown format %q", string(format))
+	}
+	return format, nil
+}
@@ -0,0 +1,101 @@
+package httpapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"todo-wails-go/internal/adapter/ical"
+	"todo-wails-go/internal/domain/models"
+)
+
+// listFeeds handles GET /feeds; the feeds come without their tokens
+func (s *Server) listFeeds(w http.ResponseWriter, r *http.Request) {
+	feeds, err := s.feeds.GetFeeds(r.Context())
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, feeds)
+}
+
+// createFeed handles POST /feeds; the response links to the new feed and is
+// the only one that carries its token
+func (s *Server) createFeed(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateFeedRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	feed, err := s.feeds.CreateFeed(r.Context(), &req)
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", feedPath(feed))
+	writeJSON(w, http.StatusCreated, feed)
+}
+
+// deleteFeed handles DELETE /feeds/{id}
+func (s *Server) deleteFeed(w http.ResponseWriter, r *http.Request) {
+	if err := s.feeds.DeleteFeed(r.Context(), r.PathValue("id")); err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// feedCalendar handles GET /feeds/{token}/tasks.ics, the URL calendar apps
+// subscribe to. The ETag is a hash of the calendar, which only changes with
+// the tasks, so polling clients get 304 Not Modified in between.
+func (s *Server) feedCalendar(w http.ResponseWriter, r *http.Request) {
+	feed, tasks, err := s.feeds.GetFeedTasks(r.Context(), r.PathValue("token"))
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	var body bytes.Buffer
+	if err := ical.EncodeCalendar(&body, &ical.Calendar{Name: feed.Name, Tasks: tasks}); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to encode calendar: %w", err))
+		return
+	}
+
+	sum := sha256.Sum256(body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+// feedPath returns the subscription path of a feed
+func feedPath(feed *models.Feed) string {
+	return "/feeds/" + feed.Token + "/tasks.ics"
+}
+
+// etagMatches reports whether an If-None-Match header lists the ETag,
+// comparing weakly as RFC 9110 requires for this header
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
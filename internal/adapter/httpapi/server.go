@@ -0,0 +1,452 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/usecase"
+)
+
+// maxBodyBytes limits the size of request bodies
+const maxBodyBytes = 1 << 20
+
+// internalErrorMessage replaces the text of internal errors in responses
+const internalErrorMessage = "internal server error"
+
+// Server exposes the task use cases as a JSON REST API, and calendar feeds
+type Server struct {
+	useCase *usecase.TaskUseCase
+	feeds   *usecase.FeedUseCase
+	mux     *http.ServeMux
+	token   string // required from API clients when set
+}
+
+// NewServer creates a new REST API server
+func NewServer(useCase *usecase.TaskUseCase, feeds *usecase.FeedUseCase, opts ...Option) *Server {
+	s := &Server{useCase: useCase, feeds: feeds, mux: http.NewServeMux()}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("GET /tasks", s.listTasks)
+	s.mux.HandleFunc("POST /tasks", s.createTask)
+	s.mux.HandleFunc("GET /tasks/overdue", s.overdueTasks)
+	s.mux.HandleFunc("GET /tasks/today", s.todayTasks)
+	s.mux.HandleFunc("GET /tasks/week", s.weekTasks)
+	s.mux.HandleFunc("GET /tasks/search", s.searchTasks)
+	s.mux.HandleFunc("GET /tasks/{id}", s.getTask)
+	s.mux.HandleFunc("PUT /tasks/{id}", s.updateTask)
+	s.mux.HandleFunc("PATCH /tasks/{id}", s.patchTask)
+	s.mux.HandleFunc("DELETE /tasks/{id}", s.deleteTask)
+	s.mux.HandleFunc("POST /tasks/{id}/toggle", s.toggleTask)
+	s.mux.HandleFunc("POST /tasks/{id}/restore", s.restoreTask)
+	s.mux.HandleFunc("GET /tasks/{id}/history", s.taskHistory)
+	s.mux.HandleFunc("GET /trash", s.listTrash)
+	s.mux.HandleFunc("DELETE /trash", s.emptyTrash)
+	s.mux.HandleFunc("GET /export", s.exportTasks)
+	s.mux.HandleFunc("POST /import", s.importTasks)
+	s.mux.HandleFunc("GET /feeds", s.listFeeds)
+	s.mux.HandleFunc("POST /feeds", s.createFeed)
+	s.mux.HandleFunc("DELETE /feeds/{id}", s.deleteFeed)
+	s.mux.HandleFunc("GET /feeds/{token}/tasks.ics", s.feedCalendar)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler. Changes are attributed to the client
+// named in the X-Actor header, if any.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeUnauthorized(w)
+		return
+	}
+	if actor := r.Header.Get("X-Actor"); actor != "" {
+		r = r.WithContext(domain.WithActor(r.Context(), actor))
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// listTasks handles GET /tasks with filters taken from the query string
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := s.useCase.ListTasks(r.Context(), filter)
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	// The body stays a plain array; paging details travel in headers
+	w.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	writeJSON(w, http.StatusOK, page.Items)
+}
+
+// createTask handles POST /tasks
+func (s *Server) createTask(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateTaskRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	task, err := s.useCase.CreateTask(r.Context(), &req)
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/tasks/"+task.ID)
+	writeJSON(w, http.StatusCreated, task)
+}
+
+// searchTasks handles GET /tasks/search?q=...; other filter parameters narrow the results
+func (s *Server) searchTasks(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if filter == nil {
+		filter = &models.FilterOptions{}
+	}
+
+	results, err := s.useCase.SearchTasks(r.Context(), filter)
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	if results == nil {
+		results = []*models.SearchResult{}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+// getTask handles GET /tasks/{id}
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request) {
+	task, err := s.useCase.GetTask(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, task)
+}
+
+// updateTask handles PUT /tasks/{id}; the ID in the path wins over the body
+func (s *Server) updateTask(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateTaskRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = r.PathValue("id")
+
+	task, err := s.useCase.UpdateTask(r.Context(), &req)
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, task)
+}
+
+// patchTask handles PATCH /tasks/{id}; only the fields present in the body change
+func (s *Server) patchTask(w http.ResponseWriter, r *http.Request) {
+	var req models.PatchTaskRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = r.PathValue("id")
+
+	task, err := s.useCase.PatchTask(r.Context(), &req)
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, task)
+}
+
+// deleteTask handles DELETE /tasks/{id}; the task goes to the trash
+func (s *Server) deleteTask(w http.ResponseWriter, r *http.Request) {
+	if err := s.useCase.DeleteTask(r.Context(), r.PathValue("id")); err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toggleTask handles POST /tasks/{id}/toggle
+func (s *Server) toggleTask(w http.ResponseWriter, r *http.Request) {
+	task, err := s.useCase.ToggleTaskStatus(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, task)
+}
+
+// restoreTask handles POST /tasks/{id}/restore
+func (s *Server) restoreTask(w http.ResponseWriter, r *http.Request) {
+	task, err := s.useCase.RestoreTask(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, task)
+}
+
+// taskHistory handles GET /tasks/{id}/history
+func (s *Server) taskHistory(w http.ResponseWriter, r *http.Request) {
+	events, err := s.useCase.GetTaskHistory(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	if events == nil {
+		events = []*models.TaskEvent{}
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+// listTrash handles GET /trash
+func (s *Server) listTrash(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.useCase.ListTrash(r.Context())
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, nonNil(tasks))
+}
+
+// emptyTrash handles DELETE /trash and reports how many tasks were purged
+func (s *Server) emptyTrash(w http.ResponseWriter, r *http.Request) {
+	n, err := s.useCase.EmptyTrash(r.Context())
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"purged": n})
+}
+
+// overdueTasks handles GET /tasks/overdue
+func (s *Server) overdueTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.useCase.GetOverdueTasks(r.Context())
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, nonNil(tasks))
+}
+
+// todayTasks handles GET /tasks/today
+func (s *Server) todayTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.useCase.GetTasksDueToday(r.Context())
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, nonNil(tasks))
+}
+
+// weekTasks handles GET /tasks/week
+func (s *Server) weekTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.useCase.GetTasksDueThisWeek(r.Context())
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, nonNil(tasks))
+}
+
+// parseFilter reads FilterOptions from query parameters such as
+// ?status=0&priority=2&anyTags=a,b&sort=priority:desc,due_date:asc
+func parseFilter(r *http.Request) (*models.FilterOptions, error) {
+	query := r.URL.Query()
+	if len(query) == 0 {
+		return nil, nil
+	}
+
+	filter := &models.FilterOptions{
+		Sort:       models.ParseSort(query.Get("sort")),
+		SortBy:     query.Get("sortBy"),
+		SortOrder:  query.Get("sortOrder"),
+		RootOnly:   query.Get("rootOnly") == "true",
+		Query:      query.Get("q"),
+		Expression: query.Get("expr"),
+		Cursor:     query.Get("cursor"),
+	}
+
+	if value := query.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, domain.Invalid("limit", "invalid limit %q", value)
+		}
+		filter.Limit = n
+	}
+
+	if value := query.Get("status"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, domain.Invalid("status", "invalid status %q", value)
+		}
+		status := models.Status(n)
+		filter.Status = &status
+	}
+
+	if value := query.Get("priority"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, domain.Invalid("priority", "invalid priority %q", value)
+		}
+		priority := models.Priority(n)
+		filter.Priority = &priority
+	}
+
+	for name, target := range map[string]**time.Time{
+		"dueFrom":     &filter.DueFrom,
+		"dueTo":       &filter.DueTo,
+		"createdFrom": &filter.CreatedFrom,
+		"createdTo":   &filter.CreatedTo,
+		"updatedFrom": &filter.UpdatedFrom,
+		"updatedTo":   &filter.UpdatedTo,
+		"dateFrom":    &filter.DateFrom, // deprecated
+		"dateTo":      &filter.DateTo,
+	} {
+		if value := query.Get(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, domain.Invalid(name, "invalid %s %q, expected RFC 3339", name, value)
+			}
+			*target = &t
+		}
+	}
+
+	if value := query.Get("hasDueDate"); value != "" {
+		has, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, domain.Invalid("hasDueDate", "invalid hasDueDate %q", value)
+		}
+		filter.HasDueDate = &has
+	}
+
+	for name, target := range map[string]**string{"parentId": &filter.ParentID, "projectId": &filter.ProjectID, "seriesId": &filter.SeriesID} {
+		if query.Has(name) {
+			value := query.Get(name)
+			*target = &value
+		}
+	}
+
+	filter.AnyTags = splitList(query.Get("anyTags"))
+	filter.AllTags = splitList(query.Get("allTags"))
+	if query.Has("tags") {
+		filter.Tags = splitList(query.Get("tags"))
+		if filter.Tags == nil {
+			filter.Tags = []string{}
+		}
+	}
+
+	return filter, nil
+}
+
+// splitList splits a comma-separated query value, ignoring empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// decodeBody decodes a size-limited JSON request body
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return domain.Invalid("", "invalid request format: %v", err)
+	}
+	return nil
+}
+
+// nonNil makes empty task lists encode as [] rather than null
+func nonNil(tasks []*models.Task) []*models.Task {
+	if tasks == nil {
+		return []*models.Task{}
+	}
+	return tasks
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// errorBody is the JSON body of an error response, shaped like handler.Error
+// so that both adapters report errors alike
+type errorBody struct {
+	Code     string      `json:"code"`
+	Message  string      `json:"message"`
+	Field    string      `json:"field,omitempty"`
+	Position int         `json:"position,omitempty"`
+	Current  interface{} `json:"current,omitempty"`
+}
+
+// writeError writes an error as {"code": "...", "message": "..."}. Internal
+// errors are logged and answered with a generic message, since their text
+// can reveal details of the store.
+func writeError(w http.ResponseWriter, status int, err error) {
+	message := err.Error()
+	if domain.Code(err) == domain.CodeInternal {
+		log.Printf("Error: %s: %v", http.StatusText(status), err)
+		message = internalErrorMessage
+	}
+
+	writeJSON(w, status, errorBody{
+		Code:     domain.Code(err),
+		Message:  message,
+		Field:    domain.Field(err),
+		Position: domain.Position(err),
+		Current:  domain.Current(err),
+	})
+}
+
+// writeUseCaseError maps a use case error to a status code by its domain class
+func writeUseCaseError(w http.ResponseWriter, err error) {
+	switch domain.Code(err) {
+	case domain.CodeNotFound:
+		writeError(w, http.StatusNotFound, err)
+	case domain.CodeValidation:
+		writeError(w, http.StatusBadRequest, err)
+	case domain.CodeConflict:
+		writeError(w, http.StatusConflict, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,252 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todo-wails-go/internal/adapter/db"
+	"todo-wails-go/internal/adapter/service"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/usecase"
+)
+
+// newTestServer returns a server on an empty in-memory store
+func newTestServer(t *testing.T, opts ...Option) *Server {
+	t.Helper()
+	repo := db.NewMemoryRepository()
+	t.Cleanup(func() { repo.Close() })
+	return NewServer(
+		usecase.NewTaskUseCase(service.NewTaskService(repo), service.NewProjectService(repo)),
+		usecase.NewFeedUseCase(service.NewFeedService(repo)),
+		opts...,
+	)
+}
+
+// serve sends a request to the server and returns the recorded response
+func serve(t *testing.T, s *Server, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+// decode unmarshals a response body, failing the test on a status other than want
+func decode(t *testing.T, rec *httptest.ResponseRecorder, want int, v interface{}) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("status = %d, want %d; body %s", rec.Code, want, rec.Body.String())
+	}
+	if v == nil {
+		return
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("invalid response body %q: %v", rec.Body.String(), err)
+	}
+}
+
+// createTask creates a task through the API
+func createTask(t *testing.T, s *Server, body string) *models.Task {
+	t.Helper()
+	var task models.Task
+	decode(t, serve(t, s, http.MethodPost, "/tasks", body), http.StatusCreated, &task)
+	return &task
+}
+
+func TestTaskLifecycle(t *testing.T) {
+	s := newTestServer(t)
+
+	created := createTask(t, s, `{"title": "Write report", "priority": 2}`)
+	if created.ID == "" || created.Title != "Write report" || created.Priority != models.PriorityHigh {
+		t.Fatalf("created task = %+v", created)
+	}
+
+	var got models.Task
+	decode(t, serve(t, s, http.MethodGet, "/tasks/"+created.ID, ""), http.StatusOK, &got)
+	if got.ID != created.ID || got.Title != created.Title {
+		t.Fatalf("got task = %+v, want %+v", got, created)
+	}
+
+	var updated models.Task
+	body := `{"title": "Write final report", "priority": 1, "status": 0, "version": 1}`
+	decode(t, serve(t, s, http.MethodPut, "/tasks/"+created.ID, body), http.StatusOK, &updated)
+	if updated.Title != "Write final report" || updated.Priority != models.PriorityMedium || updated.Version != 2 {
+		t.Fatalf("updated task = %+v", updated)
+	}
+
+	var toggled models.Task
+	decode(t, serve(t, s, http.MethodPost, "/tasks/"+created.ID+"/toggle", ""), http.StatusOK, &toggled)
+	if toggled.Status != models.StatusCompleted {
+		t.Fatalf("toggled status = %d, want completed", toggled.Status)
+	}
+	decode(t, serve(t, s, http.MethodPost, "/tasks/"+created.ID+"/toggle", ""), http.StatusOK, &toggled)
+	if toggled.Status != models.StatusActive {
+		t.Fatalf("toggled back status = %d, want active", toggled.Status)
+	}
+
+	decode(t, serve(t, s, http.MethodDelete, "/tasks/"+created.ID, ""), http.StatusNoContent, nil)
+	decode(t, serve(t, s, http.MethodGet, "/tasks/"+created.ID, ""), http.StatusNotFound, nil)
+
+	var trash []*models.Task
+	decode(t, serve(t, s, http.MethodGet, "/trash", ""), http.StatusOK, &trash)
+	if len(trash) != 1 || trash[0].ID != created.ID {
+		t.Fatalf("trash = %+v, want the deleted task", trash)
+	}
+}
+
+func TestOverdueTasks(t *testing.T) {
+	s := newTestServer(t)
+
+	past := time.Now().Add(-48 * time.Hour).Format(time.RFC3339)
+	future := time.Now().Add(48 * time.Hour).Format(time.RFC3339)
+	overdue := createTask(t, s, `{"title": "Late", "dueDate": "`+past+`"}`)
+	createTask(t, s, `{"title": "Later", "dueDate": "`+future+`"}`)
+	createTask(t, s, `{"title": "Someday"}`)
+	done := createTask(t, s, `{"title": "Late but done", "dueDate": "`+past+`"}`)
+	decode(t, serve(t, s, http.MethodPost, "/tasks/"+done.ID+"/toggle", ""), http.StatusOK, nil)
+
+	var tasks []*models.Task
+	decode(t, serve(t, s, http.MethodGet, "/tasks/overdue", ""), http.StatusOK, &tasks)
+	if len(tasks) != 1 || tasks[0].ID != overdue.ID {
+		t.Fatalf("overdue tasks = %+v, want only %q", tasks, overdue.Title)
+	}
+}
+
+func TestListTasksFiltered(t *testing.T) {
+	s := newTestServer(t)
+
+	createTask(t, s, `{"title": "Low", "priority": 0, "tags": ["home"]}`)
+	high := createTask(t, s, `{"title": "High", "priority": 2, "tags": ["work"]}`)
+	createTask(t, s, `{"title": "Also high", "priority": 2, "tags": ["home"]}`)
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", 3},
+		{"?priority=2", 2},
+		{"?priority=2&anyTags=work", 1},
+		{"?status=1", 0},
+	}
+	for _, tt := range tests {
+		rec := serve(t, s, http.MethodGet, "/tasks"+tt.query, "")
+		var tasks []*models.Task
+		decode(t, rec, http.StatusOK, &tasks)
+		if len(tasks) != tt.want {
+			t.Errorf("GET /tasks%s returned %d tasks, want %d", tt.query, len(tasks), tt.want)
+		}
+		if total := rec.Header().Get("X-Total-Count"); total == "" {
+			t.Errorf("GET /tasks%s has no X-Total-Count header", tt.query)
+		}
+	}
+
+	var tasks []*models.Task
+	decode(t, serve(t, s, http.MethodGet, "/tasks?anyTags=work", ""), http.StatusOK, &tasks)
+	if len(tasks) != 1 || tasks[0].ID != high.ID {
+		t.Errorf("tasks tagged work = %+v, want %q", tasks, high.Title)
+	}
+}
+
+func TestErrorStatuses(t *testing.T) {
+	s := newTestServer(t)
+	task := createTask(t, s, `{"title": "Existing"}`)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+		code   string
+	}{
+		{"unknown task", http.MethodGet, "/tasks/does-not-exist", "", http.StatusNotFound, "not_found"},
+		{"unknown task toggle", http.MethodPost, "/tasks/does-not-exist/toggle", "", http.StatusNotFound, "not_found"},
+		{"missing title", http.MethodPost, "/tasks", `{"title": ""}`, http.StatusBadRequest, "validation_error"},
+		{"malformed body", http.MethodPost, "/tasks", `{"title":`, http.StatusBadRequest, "validation_error"},
+		{"missing version", http.MethodPut, "/tasks/" + task.ID, `{"title": "Changed"}`, http.StatusBadRequest, "validation_error"},
+		{"invalid filter", http.MethodGet, "/tasks?priority=high", "", http.StatusBadRequest, "validation_error"},
+		{"stale version", http.MethodPut, "/tasks/" + task.ID, `{"title": "Changed", "version": 7}`, http.StatusConflict, "conflict"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body errorBody
+			decode(t, serve(t, s, tt.method, tt.path, tt.body), tt.want, &body)
+			if body.Code != tt.code {
+				t.Errorf("code = %q, want %q", body.Code, tt.code)
+			}
+			if body.Message == "" {
+				t.Errorf("error response has no message")
+			}
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	s := newTestServer(t, WithToken("s3cret"))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		header string
+		want   int
+	}{
+		{"no token", http.MethodGet, "/tasks", "", http.StatusUnauthorized},
+		{"wrong token", http.MethodDelete, "/trash", "Bearer guess", http.StatusUnauthorized},
+		{"not a bearer token", http.MethodGet, "/tasks", "s3cret", http.StatusUnauthorized},
+		{"valid token", http.MethodGet, "/tasks", "Bearer s3cret", http.StatusOK},
+		{"calendar feed", http.MethodGet, "/feeds/unknown/tasks.ics", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+
+			if tt.want != http.StatusUnauthorized {
+				decode(t, rec, tt.want, nil)
+				return
+			}
+			var body errorBody
+			decode(t, rec, tt.want, &body)
+			if body.Code != codeUnauthorized {
+				t.Errorf("code = %q, want %q", body.Code, codeUnauthorized)
+			}
+		})
+	}
+}
+
+func TestFeedTokenShownOnce(t *testing.T) {
+	s := newTestServer(t)
+
+	var feed models.Feed
+	rec := serve(t, s, http.MethodPost, "/feeds", `{"name": "Due", "filter": {"hasDueDate": true}}`)
+	decode(t, rec, http.StatusCreated, &feed)
+	if feed.Token == "" {
+		t.Fatal("created feed has no token")
+	}
+	if location := rec.Header().Get("Location"); location != "/feeds/"+feed.Token+"/tasks.ics" {
+		t.Errorf("Location = %q", location)
+	}
+
+	rec = serve(t, s, http.MethodGet, "/feeds", "")
+	var feeds []*models.Feed
+	decode(t, rec, http.StatusOK, &feeds)
+	if len(feeds) != 1 || feeds[0].ID != feed.ID {
+		t.Fatalf("feeds = %+v, want the created one", feeds)
+	}
+	if feeds[0].Token != "" || strings.Contains(rec.Body.String(), feed.Token) {
+		t.Errorf("feed list exposes the token: %s", rec.Body.String())
+	}
+
+	decode(t, serve(t, s, http.MethodGet, "/feeds/"+feed.Token+"/tasks.ics", ""), http.StatusOK, nil)
+}
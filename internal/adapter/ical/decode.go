@@ -0,0 +1,273 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// Decode reads the VTODO components of one or more VCALENDARs. Components
+// other than VTODO are skipped, as are properties that have no task field
+// and recurrence rules outside the supported subset.
+func Decode(r io.Reader) ([]*models.Task, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tasks    []*models.Task
+		task     *models.Task
+		status   string
+		depth    int // components opened inside the current VTODO
+		calendar bool
+	)
+	for _, l := range lines {
+		prop, err := parseProperty(l.text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", l.number, err)
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VCALENDAR"):
+			calendar = true
+		case prop.name == "BEGIN" && task == nil && strings.EqualFold(prop.value, "VTODO"):
+			task = &models.Task{}
+			status = ""
+		case prop.name == "BEGIN" && task != nil:
+			depth++
+		case prop.name == "END" && task != nil && depth > 0:
+			depth--
+		case prop.name == "END" && task != nil:
+			if status == "COMPLETED" {
+				task.Status = models.StatusCompleted
+			}
+			if task.Title == "" {
+				return nil, fmt.Errorf("line %d: VTODO has no SUMMARY", l.number)
+			}
+			tasks = append(tasks, task)
+			task = nil
+		case task != nil && depth == 0:
+			if err := setProperty(task, prop, &status); err != nil {
+				return nil, fmt.Errorf("line %d: %s: %w", l.number, prop.name, err)
+			}
+		}
+	}
+
+	if !calendar {
+		return nil, fmt.Errorf("no VCALENDAR found")
+	}
+	if task != nil {
+		return nil, fmt.Errorf("VTODO is not closed")
+	}
+	return tasks, nil
+}
+
+// setProperty copies a VTODO property into the task; status collects STATUS
+// and COMPLETED, which are resolved at the end of the component
+func setProperty(task *models.Task, prop *property, status *string) error {
+	switch prop.name {
+	case "UID":
+		task.ID = prop.value
+	case "SUMMARY":
+		task.Title = unescapeText(prop.value)
+	case "DESCRIPTION":
+		task.Description = unescapeText(prop.value)
+	case "PRIORITY":
+		n, err := strconv.Atoi(prop.value)
+		if err != nil || n < 0 || n > 9 {
+			return fmt.Errorf("invalid priority %q", prop.value)
+		}
+		task.Priority = decodePriority(n)
+	case "STATUS":
+		if *status != "COMPLETED" {
+			*status = strings.ToUpper(prop.value)
+		}
+	case "COMPLETED":
+		*status = "COMPLETED"
+	case "DUE":
+		due, err := parseTime(prop)
+		if err != nil {
+			return err
+		}
+		task.DueDate = &due
+	case "CREATED":
+		created, err := parseTime(prop)
+		if err != nil {
+			return err
+		}
+		task.CreatedAt = created
+	case "LAST-MODIFIED":
+		modified, err := parseTime(prop)
+		if err != nil {
+			return err
+		}
+		task.UpdatedAt = modified
+	case "CATEGORIES":
+		for _, name := range splitText(prop.value) {
+			if name != "" {
+				task.Tags = append(task.Tags, models.Tag{Name: name})
+			}
+		}
+	case "RRULE":
+		if recurrence, err := models.ParseRecurrence(prop.value); err == nil && recurrence.Validate() == nil {
+			task.Recurrence = recurrence
+		}
+	case "RELATED-TO":
+		if reltype := prop.params["RELTYPE"]; reltype == "" || strings.EqualFold(reltype, "PARENT") {
+			parentID := prop.value
+			task.ParentID = &parentID
+		}
+	}
+	return nil
+}
+
+// decodePriority maps the RFC 5545 scale onto a priority: 1-4 is high, 5 is
+// medium and 6-9 or undefined (0) is low
+func decodePriority(n int) models.Priority {
+	switch {
+	case n >= 1 && n <= 4:
+		return models.PriorityHigh
+	case n == 5:
+		return models.PriorityMedium
+	default:
+		return models.PriorityLow
+	}
+}
+
+// parseTime reads a DATE or DATE-TIME value: UTC, with a TZID or floating,
+// which is taken as local time like a date is
+func parseTime(prop *property) (time.Time, error) {
+	loc := time.Local
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	value := prop.value
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse(dateTimeLayout, value)
+	case len(value) == len("20060102"):
+		return time.ParseInLocation("20060102", value, loc)
+	default:
+		return time.ParseInLocation("20060102T150405", value, loc)
+	}
+}
+
+// line is an unfolded content line with the number of its first physical line
+type line struct {
+	number int
+	text   string
+}
+
+// unfold joins folded content lines; CRLF and bare LF line ends are accepted
+func unfold(r io.Reader) ([]line, error) {
+	var lines []line
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if len(text) > 0 && (text[0] == ' ' || text[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if text != "" {
+			lines = append(lines, line{number: number, text: text})
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// property is a parsed content line
+type property struct {
+	name   string // upper case
+	params map[string]string
+	value  string
+}
+
+// parseProperty splits "NAME;PARAM=value;...:value", allowing quoted
+// parameter values that contain ':' or ';'
+func parseProperty(text string) (*property, error) {
+	prop := &property{params: make(map[string]string)}
+
+	quoted := false
+	start := 0
+	var parts []string
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == ';':
+			parts = append(parts, text[start:i])
+			start = i + 1
+		case c == ':':
+			parts = append(parts, text[start:i])
+			prop.value = text[i+1:]
+
+			prop.name = strings.ToUpper(parts[0])
+			if prop.name == "" {
+				return nil, fmt.Errorf("property name is missing")
+			}
+			for _, param := range parts[1:] {
+				key, value, _ := strings.Cut(param, "=")
+				prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+			}
+			return prop, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid content line %q", text)
+}
+
+// splitText splits a comma-separated list of TEXT values and unescapes them
+func splitText(value string) []string {
+	var values []string
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '\\' && i+1 < len(value):
+			b.WriteByte('\\')
+			b.WriteByte(value[i+1])
+			i++
+		case c == ',':
+			values = append(values, unescapeText(b.String()))
+			b.Reset()
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return append(values, unescapeText(b.String()))
+}
+
+// unescapeText reverses the TEXT escapes
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,153 @@
+// Package ical reads and writes tasks as RFC 5545 iCalendar VTODO components.
+//
+// Title maps to SUMMARY, Description to DESCRIPTION, Priority to PRIORITY,
+// Status to STATUS and COMPLETED, DueDate to DUE, Tags to CATEGORIES,
+// Recurrence to RRULE and ParentID to RELATED-TO. The task ID is the UID.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// prodID identifies this application as the producer of a calendar
+const prodID = "-//todo-wails-go//Tasks//EN"
+
+// dateTimeLayout is the UTC DATE-TIME form written for every timestamp
+const dateTimeLayout = "20060102T150405Z"
+
+// maxLineOctets is the longest content line before folding, without the CRLF
+const maxLineOctets = 75
+
+// Calendar is a VCALENDAR of tasks
+type Calendar struct {
+	Name  string // shown by calendar apps as the calendar name; optional
+	Tasks []*models.Task
+}
+
+// Encode writes the tasks as one VCALENDAR
+func Encode(w io.Writer, tasks []*models.Task) error {
+	return EncodeCalendar(w, &Calendar{Tasks: tasks})
+}
+
+// EncodeCalendar writes a calendar. The output depends only on the tasks,
+// so an unchanged calendar encodes to the same bytes.
+func EncodeCalendar(w io.Writer, calendar *Calendar) error {
+	e := &encoder{w: bufio.NewWriter(w)}
+
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", prodID)
+	e.line("CALSCALE", "GREGORIAN")
+	if calendar.Name != "" {
+		e.line("X-WR-CALNAME", escapeText(calendar.Name))
+	}
+	for _, task := range calendar.Tasks {
+		e.task(task)
+	}
+	e.line("END", "VCALENDAR")
+
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// encoder writes content lines, keeping the first error
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+// task writes one VTODO
+func (e *encoder) task(task *models.Task) {
+	e.line("BEGIN", "VTODO")
+	e.line("UID", task.ID)
+	e.line("DTSTAMP", formatTime(task.UpdatedAt))
+	e.line("CREATED", formatTime(task.CreatedAt))
+	e.line("LAST-MODIFIED", formatTime(task.UpdatedAt))
+	e.line("SUMMARY", escapeText(task.Title))
+	if task.Description != "" {
+		e.line("DESCRIPTION", escapeText(task.Description))
+	}
+	e.line("PRIORITY", strconv.Itoa(encodePriority(task.Priority)))
+	if task.Status == models.StatusCompleted {
+		// The completion time is not kept; the last change is the closest to it
+		e.line("STATUS", "COMPLETED")
+		e.line("COMPLETED", formatTime(task.UpdatedAt))
+	} else {
+		e.line("STATUS", "NEEDS-ACTION")
+	}
+	if task.DueDate != nil {
+		e.line("DUE", formatTime(*task.DueDate))
+	}
+	if len(task.Tags) > 0 {
+		names := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			names[i] = escapeText(tag.Name)
+		}
+		e.line("CATEGORIES", strings.Join(names, ","))
+	}
+	if task.Recurrence != nil {
+		e.line("RRULE", task.Recurrence.String())
+	}
+	if task.ParentID != nil {
+		e.line("RELATED-TO;RELTYPE=PARENT", *task.ParentID)
+	}
+	e.line("END", "VTODO")
+}
+
+// line writes a content line, folding it after every 75 octets without
+// splitting a UTF-8 sequence
+func (e *encoder) line(name, value string) {
+	if e.err != nil {
+		return
+	}
+
+	line := name + ":" + value
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > maxLineOctets {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+
+	_, e.err = e.w.WriteString(b.String())
+}
+
+// encodePriority maps a priority onto the RFC 5545 scale, where 1 is the highest
+func encodePriority(priority models.Priority) int {
+	switch priority {
+	case models.PriorityHigh:
+		return 1
+	case models.PriorityMedium:
+		return 5
+	default:
+		return 9
+	}
+}
+
+// formatTime writes a UTC DATE-TIME
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+// textEscaper escapes TEXT values; every line break, including a bare CR,
+// becomes \n since a raw one would end the content line
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escapeText escapes a TEXT value
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
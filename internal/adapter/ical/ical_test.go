@@ -0,0 +1,174 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// roundTrip encodes the tasks and decodes the result
+func roundTrip(t *testing.T, tasks []*models.Task) (string, []*models.Task) {
+	t.Helper()
+	var b bytes.Buffer
+	if err := Encode(&b, tasks); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	decoded, err := Decode(bytes.NewReader(b.Bytes()))
+	if err != nil {
+		t.Fatalf("Decode: %v\n%s", err, b.String())
+	}
+	if len(decoded) != len(tasks) {
+		t.Fatalf("decoded %d tasks, want %d", len(decoded), len(tasks))
+	}
+	return b.String(), decoded
+}
+
+func TestRoundTrip(t *testing.T) {
+	created := time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)
+	due := time.Date(2026, 3, 5, 17, 0, 0, 0, time.UTC)
+	recurrence, err := models.ParseRecurrence("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=6")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tasks := []*models.Task{
+		{
+			ID:          "7f0c7d9e-2b1a-4b8e-9a53-0f5f1d1c2a01",
+			Title:       "Call the landlord",
+			Description: "About the heating",
+			Priority:    models.PriorityHigh,
+			Status:      models.StatusActive,
+			DueDate:     &due,
+			Recurrence:  recurrence,
+			CreatedAt:   created,
+			UpdatedAt:   created,
+		},
+		{
+			ID:        "7f0c7d9e-2b1a-4b8e-9a53-0f5f1d1c2a02",
+			Title:     "Pay rent",
+			Priority:  models.PriorityMedium,
+			Status:    models.StatusCompleted,
+			CreatedAt: created,
+			UpdatedAt: created.Add(time.Hour),
+		},
+		{
+			ID:        "7f0c7d9e-2b1a-4b8e-9a53-0f5f1d1c2a03",
+			Title:     "Someday",
+			Priority:  models.PriorityLow,
+			Status:    models.StatusActive,
+			CreatedAt: created,
+			UpdatedAt: created,
+		},
+	}
+
+	out, decoded := roundTrip(t, tasks)
+	for _, want := range []string{"STATUS:COMPLETED", "COMPLETED:20260301T093000Z", "PRIORITY:1", "PRIORITY:5", "PRIORITY:9"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output has no %q:\n%s", want, out)
+		}
+	}
+
+	for i, got := range decoded {
+		want := tasks[i]
+		if got.ID != want.ID {
+			t.Errorf("task %d: UID = %q, want %q", i, got.ID, want.ID)
+		}
+		if got.Title != want.Title {
+			t.Errorf("task %d: SUMMARY = %q, want %q", i, got.Title, want.Title)
+		}
+		if got.Description != want.Description {
+			t.Errorf("task %d: DESCRIPTION = %q, want %q", i, got.Description, want.Description)
+		}
+		if got.Priority != want.Priority {
+			t.Errorf("task %d: PRIORITY = %d, want %d", i, got.Priority, want.Priority)
+		}
+		if got.Status != want.Status {
+			t.Errorf("task %d: STATUS = %d, want %d", i, got.Status, want.Status)
+		}
+		switch {
+		case (got.DueDate == nil) != (want.DueDate == nil):
+			t.Errorf("task %d: DUE = %v, want %v", i, got.DueDate, want.DueDate)
+		case got.DueDate != nil && !got.DueDate.Equal(*want.DueDate):
+			t.Errorf("task %d: DUE = %v, want %v", i, *got.DueDate, *want.DueDate)
+		}
+		switch {
+		case (got.Recurrence == nil) != (want.Recurrence == nil):
+			t.Errorf("task %d: RRULE = %v, want %v", i, got.Recurrence, want.Recurrence)
+		case got.Recurrence != nil && got.Recurrence.String() != want.Recurrence.String():
+			t.Errorf("task %d: RRULE = %q, want %q", i, got.Recurrence.String(), want.Recurrence.String())
+		}
+		if !got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) {
+			t.Errorf("task %d: CREATED/LAST-MODIFIED = %v/%v, want %v/%v", i, got.CreatedAt, got.UpdatedAt, want.CreatedAt, want.UpdatedAt)
+		}
+	}
+}
+
+func TestRoundTripEscaping(t *testing.T) {
+	now := time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		wantDesc    string // the description after decoding
+	}{
+		{"separators", "Milk, eggs; bread", `C:\temp\list`, `C:\temp\list`},
+		{"line breaks", "Notes", "first\nsecond\r\nthird", "first\nsecond\nthird"},
+		{"bare carriage return", "Old Mac notes", "first\rsecond", "first\nsecond"},
+		{"escape-like text", `Literal \n and \, stay`, `\;`, `\;`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &models.Task{ID: "x", Title: tt.title, Description: tt.description, CreatedAt: now, UpdatedAt: now}
+			out, decoded := roundTrip(t, []*models.Task{task})
+
+			if strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\r") {
+				t.Errorf("output has a raw CR outside line ends:\n%q", out)
+			}
+			if decoded[0].Title != tt.title {
+				t.Errorf("SUMMARY = %q, want %q", decoded[0].Title, tt.title)
+			}
+			if decoded[0].Description != tt.wantDesc {
+				t.Errorf("DESCRIPTION = %q, want %q", decoded[0].Description, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestRoundTripFolding(t *testing.T) {
+	now := time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		title string
+	}{
+		{"ascii", strings.Repeat("abcdefghij", 20)},
+		{"exactly one line", strings.Repeat("a", maxLineOctets-len("SUMMARY:"))},
+		{"one octet over", strings.Repeat("a", maxLineOctets-len("SUMMARY:")+1)},
+		{"multibyte", strings.Repeat("задача ", 30)},
+		{"emoji", strings.Repeat("✅🗓️", 40)},
+		{"escapes across folds", strings.Repeat("a,b;c\\", 30)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &models.Task{ID: "x", Title: tt.title, Description: tt.title, CreatedAt: now, UpdatedAt: now}
+			out, decoded := roundTrip(t, []*models.Task{task})
+
+			for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+				if len(line) > maxLineOctets {
+					t.Errorf("line of %d octets exceeds %d: %q", len(line), maxLineOctets, line)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("fold splits a UTF-8 sequence: %q", line)
+				}
+			}
+			if decoded[0].Title != tt.title || decoded[0].Description != tt.title {
+				t.Errorf("SUMMARY/DESCRIPTION = %q/%q, want %q", decoded[0].Title, decoded[0].Description, tt.title)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/user"
+	"reflect"
+	"time"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+)
+
+// GetTaskHistory retrieves the audit log of a task, oldest change first. The
+// log stays available after the task has been deleted for good.
+func (s *TaskService) GetTaskHistory(ctx context.Context, id string) ([]*models.TaskEvent, error) {
+	if id == "" {
+		return nil, domain.Required("id")
+	}
+
+	events, err := s.repo.GetTaskEvents(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task history: %w", err)
+	}
+
+	if len(events) == 0 {
+		if _, err := s.repo.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// LocalActor names the user of this machine, for attributing the changes
+// made by the desktop app and the command line client
+func LocalActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// audit appends an event for every task whose audited fields differ between
+// the two sides of the changes. The mutation has already been saved, so a
+// failure is logged rather than reported to the caller.
+func (s *TaskService) audit(ctx context.Context, action models.Action, target string, changes []*change, undo bool) {
+	at := time.Now()
+	actor := domain.Actor(ctx)
+
+	var events []*models.TaskEvent
+	for _, ch := range changes {
+		from, to := ch.before, ch.after
+		if undo {
+			from, to = to, from
+		}
+
+		diff := diffTask(from, to)
+		if len(diff) == 0 {
+			continue
+		}
+
+		event := &models.TaskEvent{
+			TaskID:  ch.id(),
+			Action:  action,
+			Actor:   actor,
+			Changes: diff,
+			At:      at,
+		}
+		if event.TaskID != target {
+			event.Via = target
+		}
+		events = append(events, event)
+	}
+
+	if len(events) == 0 {
+		return
+	}
+	if err := s.repo.AppendTaskEvents(ctx, events); err != nil {
+		log.Printf("Warning: Failed to record task history: %v", err)
+	}
+}
+
+// diffTask lists the audited fields that differ between two states of a
+// task; nil stands for a task that does not exist
+func diffTask(before, after *models.Task) []models.FieldChange {
+	from, to := auditedFields(before), auditedFields(after)
+
+	var changes []models.FieldChange
+	for i, field := range auditedFieldNames {
+		if !reflect.DeepEqual(from[i], to[i]) {
+			changes = append(changes, models.FieldChange{Field: field, From: from[i], To: to[i]})
+		}
+	}
+	return changes
+}
+
+// auditedFieldNames are the task fields recorded in the audit log; the
+// timestamps and the version change with every write and are left out
+var auditedFieldNames = []models.TaskField{
+	models.FieldTitle,
+	models.FieldDescription,
+	models.FieldPriority,
+	models.FieldStatus,
+	models.FieldDueDate,
+	models.FieldTags,
+	models.FieldProjectID,
+	models.FieldParentID,
+	models.FieldRecurrence,
+	models.FieldDeletedAt,
+}
+
+// auditedFields returns the values of auditedFieldNames, with nil for unset
+// and empty values so that a new task only lists what it was created with
+func auditedFields(task *models.Task) []interface{} {
+	values := make([]interface{}, len(auditedFieldNames))
+	if task == nil {
+		return values
+	}
+
+	values[0] = task.Title
+	if task.Description != "" {
+		values[1] = task.Description
+	}
+	values[2] = task.Priority
+	values[3] = task.Status
+	if task.DueDate != nil {
+		values[4] = task.DueDate.UTC()
+	}
+	if len(task.Tags) > 0 {
+		names := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			names[i] = tag.Name
+		}
+		values[5] = names
+	}
+	if task.ProjectID != nil {
+		values[6] = *task.ProjectID
+	}
+	if task.ParentID != nil {
+		values[7] = *task.ParentID
+	}
+	if task.Recurrence != nil {
+		values[8] = task.Recurrence.String()
+	}
+	if task.DeletedAt != nil {
+		values[9] = task.DeletedAt.UTC()
+	}
+	return values
+}
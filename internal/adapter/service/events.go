@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
+)
+
+// WithEventPublisher sets where the service announces task changes; without
+// one, changes are not announced
+func WithEventPublisher(publisher ports.EventPublisher) Option {
+	return func(s *TaskService) {
+		s.events = publisher
+	}
+}
+
+// publish announces every task a command changed, in the order it changed them
+func (s *TaskService) publish(ctx context.Context, action models.Action, changes []*change, undo bool) {
+	if s.events == nil {
+		return
+	}
+
+	at := time.Now()
+	actor := domain.Actor(ctx)
+	for _, ch := range changes {
+		from, to := ch.sides(undo)
+		eventType, ok := eventTypeOf(from, to)
+		if !ok {
+			continue
+		}
+
+		task := to
+		if task == nil {
+			task = from
+		}
+		s.events.Publish(ctx, models.Event{
+			Type:   eventType,
+			TaskID: task.ID,
+			Task:   task,
+			Action: action,
+			Actor:  actor,
+			At:     at,
+		})
+	}
+}
+
+// eventTypeOf classifies the change of a task between two states. Moving a
+// task into the trash deletes it and taking it out creates it again, as far
+// as the lists are concerned; purging it from the trash deletes it once more,
+// for the trash list. A write that left the task as it was is none.
+func eventTypeOf(from, to *models.Task) (models.EventType, bool) {
+	live := func(task *models.Task) bool {
+		return task != nil && task.DeletedAt == nil
+	}
+
+	switch {
+	case from != nil && to == nil:
+		return models.EventTaskDeleted, true
+	case !live(from) && live(to):
+		return models.EventTaskCreated, true
+	case live(from) && !live(to):
+		return models.EventTaskDeleted, true
+	case !live(from):
+		return "", false
+	case from.Status != to.Status:
+		return models.EventTaskStatusChanged, true
+	case sameState(from, to):
+		return "", false
+	default:
+		return models.EventTaskUpdated, true
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+// feedTokenBytes is the amount of randomness in a feed token
+const feedTokenBytes = 24
+
+// FeedService implements the calendar feed business logic
+type FeedService struct {
+	repo ports.TaskRepository
+}
+
+// NewFeedService creates a new feed service
+func NewFeedService(repo ports.TaskRepository) ports.FeedService {
+	return &FeedService{repo: repo}
+}
+
+// CreateFeed saves a filter as a feed with a new secret token
+func (s *FeedService) CreateFeed(ctx context.Context, req *models.CreateFeedRequest) (*models.Feed, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, domain.Required("name")
+	}
+
+	// A feed always serves every matching task
+	filter := req.Filter
+	filter.Limit = 0
+	filter.Cursor = ""
+	if _, err := filter.SortKeys(); err != nil {
+		return nil, domain.Invalid("filter", "%v", err)
+	}
+	if _, err := s.repo.Count(ctx, &filter); err != nil {
+		return nil, err
+	}
+
+	token, err := newFeedToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create feed token: %w", err)
+	}
+
+	feed := &models.Feed{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Token:     token,
+		Filter:    filter,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateFeed(ctx, feed); err != nil {
+		return nil, fmt.Errorf("failed to create feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+// GetFeeds retrieves every feed, oldest first, without their tokens: a token
+// is only shown once, by CreateFeed
+func (s *FeedService) GetFeeds(ctx context.Context) ([]*models.Feed, error) {
+	feeds, err := s.repo.GetFeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, feed := range feeds {
+		feed.Token = ""
+	}
+	return feeds, nil
+}
+
+// DeleteFeed deletes a feed; its URL stops working
+func (s *FeedService) DeleteFeed(ctx context.Context, id string) error {
+	if id == "" {
+		return domain.Required("id")
+	}
+	return s.repo.DeleteFeed(ctx, id)
+}
+
+// GetFeedTasks retrieves the feed with the given token and the tasks that
+// currently match its filter
+func (s *FeedService) GetFeedTasks(ctx context.Context, token string) (*models.Feed, []*models.Task, error) {
+	if token == "" {
+		return nil, nil, domain.NotFound("feed")
+	}
+
+	feed, err := s.repo.GetFeedByToken(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tasks, err := s.repo.GetAll(ctx, &feed.Filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get feed tasks: %w", err)
+	}
+
+	return feed, tasks, nil
+}
+
+// newFeedToken returns a random URL-safe token
+func newFeedToken() (string, error) {
+	b := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
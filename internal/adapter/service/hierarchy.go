@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+)
+
+// maxTaskDepth guards ancestor walks against corrupted data
+const maxTaskDepth = 1000
+
+// Option configures a TaskService
+type Option func(*TaskService)
+
+// WithCompletePolicy sets what happens to active subtasks when their parent is completed
+func WithCompletePolicy(policy models.ChildPolicy) Option {
+	return func(s *TaskService) {
+		if policy.Valid() {
+			s.completePolicy = policy
+		}
+	}
+}
+
+// WithDeletePolicy sets what happens to subtasks when their parent is deleted
+func WithDeletePolicy(policy models.ChildPolicy) Option {
+	return func(s *TaskService) {
+		if policy.Valid() {
+			s.deletePolicy = policy
+		}
+	}
+}
+
+// PoliciesFromEnv reads the subtask policies from TODO_SUBTASK_COMPLETE_POLICY and
+// TODO_SUBTASK_DELETE_POLICY ("cascade", "block" or "orphan") and the trash
+// retention from TODO_TRASH_RETENTION_DAYS; unset or invalid values keep the defaults
+func PoliciesFromEnv() []Option {
+	return []Option{
+		WithCompletePolicy(models.ChildPolicy(os.Getenv("TODO_SUBTASK_COMPLETE_POLICY"))),
+		WithDeletePolicy(models.ChildPolicy(os.Getenv("TODO_SUBTASK_DELETE_POLICY"))),
+		trashRetentionFromEnv(),
+	}
+}
+
+// GetChildren retrieves the direct subtasks of a task
+func (s *TaskService) GetChildren(ctx context.Context, id string) ([]*models.Task, error) {
+	if id == "" {
+		return nil, domain.Required("id")
+	}
+
+	if _, err := s.getTask(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	filter := &models.FilterOptions{
+		ParentID: &id,
+		Sort:     []models.SortKey{{Field: models.SortCreatedAt, Direction: models.SortAsc}},
+	}
+	return s.repo.GetAll(ctx, filter)
+}
+
+// GetSubtree retrieves a task with all of its descendants and their completion roll-up
+func (s *TaskService) GetSubtree(ctx context.Context, id string) (*models.TaskNode, error) {
+	if id == "" {
+		return nil, domain.Required("id")
+	}
+
+	root, err := s.getTask(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	descendants, err := s.liveDescendants(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := map[string]*models.TaskNode{root.ID: {Task: root, Children: []*models.TaskNode{}}}
+	for _, task := range descendants {
+		node := &models.TaskNode{Task: task, Children: []*models.TaskNode{}}
+		nodes[task.ID] = node
+		if parent, ok := nodes[*task.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	rollUp(nodes[root.ID])
+	return nodes[root.ID], nil
+}
+
+// rollUp computes the completion percentage of a node: leaves count as 0 or 100,
+// parents as the mean of their children
+func rollUp(node *models.TaskNode) float64 {
+	if len(node.Children) == 0 {
+		if node.Task.Status == models.StatusCompleted {
+			node.Progress = 100
+		} else {
+			node.Progress = 0
+		}
+		return node.Progress
+	}
+
+	var total float64
+	for _, child := range node.Children {
+		total += rollUp(child)
+	}
+	node.Progress = total / float64(len(node.Children))
+	return node.Progress
+}
+
+// MoveTask re-parents a task, rejecting moves that would create a cycle
+func (s *TaskService) MoveTask(ctx context.Context, req *models.MoveTaskRequest) (*models.Task, error) {
+	return s.record(ctx, models.ActionMove, func(ctx context.Context) (*models.Task, error) {
+		return s.moveTask(ctx, req)
+	})
+}
+
+// moveTask implements MoveTask within the recorded command
+func (s *TaskService) moveTask(ctx context.Context, req *models.MoveTaskRequest) (*models.Task, error) {
+	if req.ID == "" {
+		return nil, domain.Required("id")
+	}
+
+	task, err := s.getTask(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if req.ParentID == "" {
+		task.ParentID = nil
+	} else {
+		if err := s.validateParent(ctx, task.ID, req.ParentID); err != nil {
+			return nil, err
+		}
+		parentID := req.ParentID
+		task.ParentID = &parentID
+	}
+
+	task.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return task, nil
+}
+
+// validateParent checks that parentID exists and is not taskID or one of its descendants
+func (s *TaskService) validateParent(ctx context.Context, taskID, parentID string) error {
+	current := parentID
+	for depth := 0; current != ""; depth++ {
+		if current == taskID {
+			return domain.Invalid("parentId", "a task cannot be nested under itself or its subtasks")
+		}
+		if depth >= maxTaskDepth {
+			return domain.Invalid("parentId", "task hierarchy is deeper than %d levels", maxTaskDepth)
+		}
+
+		ancestor, err := s.getTask(ctx, current)
+		if err != nil {
+			return fmt.Errorf("failed to get parent task: %w", err)
+		}
+
+		current = ""
+		if ancestor.ParentID != nil {
+			current = *ancestor.ParentID
+		}
+	}
+	return nil
+}
+
+// applyCompletePolicy handles the active subtasks of a task that is being completed
+func (s *TaskService) applyCompletePolicy(ctx context.Context, task *models.Task) error {
+	descendants, err := s.liveDescendants(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range descendants {
+		if child.Status != models.StatusActive {
+			continue
+		}
+
+		switch s.completePolicy {
+		case models.ChildPolicyBlock:
+			return domain.Conflict("task has unfinished subtasks")
+		case models.ChildPolicyOrphan:
+			// Only direct children are detached; their own subtrees move with them
+			if *child.ParentID != task.ID {
+				continue
+			}
+			child.ParentID = nil
+		default:
+			child.Status = models.StatusCompleted
+		}
+
+		child.UpdatedAt = time.Now()
+		if err := s.repo.Update(ctx, child); err != nil {
+			return fmt.Errorf("failed to update subtask: %w", err)
+		}
+
+		if child.Status == models.StatusCompleted {
+			if err := s.spawnNextOccurrence(ctx, child); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyDeletePolicy handles the live subtasks of a task that is being moved to the trash at deletedAt
+func (s *TaskService) applyDeletePolicy(ctx context.Context, task *models.Task, deletedAt time.Time) error {
+	descendants, err := s.liveDescendants(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(descendants) == 0 {
+		return nil
+	}
+
+	switch s.deletePolicy {
+	case models.ChildPolicyBlock:
+		return domain.Conflict("task has subtasks")
+	case models.ChildPolicyCascade:
+		for _, child := range descendants {
+			if err := s.setDeletedAt(ctx, child, &deletedAt); err != nil {
+				return fmt.Errorf("failed to delete subtask: %w", err)
+			}
+		}
+	default:
+		// Only direct children are detached; their own subtrees move with them
+		for _, child := range descendants {
+			if *child.ParentID != task.ID {
+				continue
+			}
+			child.ParentID = nil
+			child.UpdatedAt = time.Now()
+			if err := s.repo.Patch(ctx, child, []models.TaskField{models.FieldParentID}); err != nil {
+				return fmt.Errorf("failed to update subtask: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,389 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
+)
+
+// defaultHistoryLimit bounds the undo history kept for a session
+const defaultHistoryLimit = 100
+
+// WithHistoryLimit sets how many mutations can be undone; non-positive values keep the default
+func WithHistoryLimit(limit int) Option {
+	return func(s *TaskService) {
+		if limit > 0 {
+			s.history.limit = limit
+		}
+	}
+}
+
+// change is the state of one task before and after a command; nil means the
+// task did not exist
+type change struct {
+	before, after *models.Task
+}
+
+// command is one recorded mutation: every task write it made, in order
+type command struct {
+	entry   models.HistoryEntry
+	changes []*change
+	byID    map[string]*change
+}
+
+// add records a write; a task written several times keeps its first state
+// before and its last state after the command
+func (c *command) add(id string, before, after *models.Task) {
+	if existing, ok := c.byID[id]; ok {
+		existing.after = after
+		return
+	}
+	ch := &change{before: before, after: after}
+	c.byID[id] = ch
+	c.changes = append(c.changes, ch)
+}
+
+// history holds the undo and redo stacks of a session
+type history struct {
+	mutex sync.Mutex
+	limit int
+	undo  []*command
+	redo  []*command
+}
+
+// push records a new command, dropping the oldest beyond the limit; a new
+// mutation makes the undone ones unreachable, as in any editor
+func (h *history) push(cmd *command) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.undo = append(h.undo, cmd)
+	if len(h.undo) > h.limit {
+		h.undo = h.undo[len(h.undo)-h.limit:]
+	}
+	h.redo = nil
+}
+
+// commandKey carries the command being recorded in a context
+type commandKey struct{}
+
+// recordingRepository records the task writes made while a command runs in
+// the context; outside of one it only passes calls through
+type recordingRepository struct {
+	ports.TaskRepository
+}
+
+// Create creates a task and records it
+func (r *recordingRepository) Create(ctx context.Context, task *models.Task) error {
+	if err := r.TaskRepository.Create(ctx, task); err != nil {
+		return err
+	}
+	return r.record(ctx, task.ID, nil)
+}
+
+// Update updates a task and records its states before and after
+func (r *recordingRepository) Update(ctx context.Context, task *models.Task) error {
+	before, err := r.snapshot(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.TaskRepository.Update(ctx, task); err != nil {
+		return err
+	}
+	return r.record(ctx, task.ID, before)
+}
+
+// Patch patches a task and records its states before and after
+func (r *recordingRepository) Patch(ctx context.Context, task *models.Task, fields []models.TaskField) error {
+	before, err := r.snapshot(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.TaskRepository.Patch(ctx, task, fields); err != nil {
+		return err
+	}
+	return r.record(ctx, task.ID, before)
+}
+
+// Delete deletes a task and records its state before
+func (r *recordingRepository) Delete(ctx context.Context, id string) error {
+	before, err := r.snapshot(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.TaskRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.record(ctx, id, before)
+}
+
+// snapshot reads the stored state of a task when a command is being
+// recorded; it returns nil otherwise and for a missing task
+func (r *recordingRepository) snapshot(ctx context.Context, id string) (*models.Task, error) {
+	if ctx.Value(commandKey{}) == nil {
+		return nil, nil
+	}
+	task, err := r.TaskRepository.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	return task, err
+}
+
+// record adds a write to the command in ctx, reading the state after it
+func (r *recordingRepository) record(ctx context.Context, id string, before *models.Task) error {
+	cmd, ok := ctx.Value(commandKey{}).(*command)
+	if !ok {
+		return nil
+	}
+	after, err := r.snapshot(ctx, id)
+	if err != nil {
+		return err
+	}
+	cmd.add(id, before, after)
+	return nil
+}
+
+// record runs a mutation as an undoable command. Mutations called from within
+// another one become part of it. A mutation that fails after some of its
+// writes is rolled back, so that it leaves the tasks as they were.
+func (s *TaskService) record(ctx context.Context, action models.Action, fn func(ctx context.Context) (*models.Task, error)) (*models.Task, error) {
+	if ctx.Value(commandKey{}) != nil {
+		return fn(ctx)
+	}
+
+	cmd := &command{byID: make(map[string]*change)}
+	task, err := fn(context.WithValue(ctx, commandKey{}, cmd))
+	if err != nil {
+		if rollbackErr := s.revert(ctx, cmd.changes, func(ch *change) *models.Task { return ch.before }); rollbackErr != nil {
+			log.Printf("Warning: Failed to roll back %s: %v", action, rollbackErr)
+		}
+		return task, err
+	}
+	if len(cmd.changes) == 0 {
+		return task, nil
+	}
+
+	cmd.entry = models.HistoryEntry{
+		Action: action,
+		TaskID: task.ID,
+		Title:  task.Title,
+		Tasks:  len(cmd.changes),
+		At:     time.Now(),
+	}
+	s.history.push(cmd)
+	s.audit(ctx, action, task.ID, cmd.changes, false)
+	s.publish(ctx, action, cmd.changes, false)
+	return task, nil
+}
+
+// Undo reverts the most recent mutation, putting every task it changed back
+// into its exact earlier state, timestamps included. Versions keep growing so
+// that edits based on the replaced state are still rejected as stale.
+func (s *TaskService) Undo(ctx context.Context) (*models.HistoryEntry, error) {
+	return s.step(ctx, true)
+}
+
+// Redo applies the most recently undone mutation again
+func (s *TaskService) Redo(ctx context.Context) (*models.HistoryEntry, error) {
+	return s.step(ctx, false)
+}
+
+// History lists the mutations that can be undone and redone
+func (s *TaskService) History(ctx context.Context) (*models.History, error) {
+	h := &s.history
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	result := &models.History{
+		Undo: make([]models.HistoryEntry, 0, len(h.undo)),
+		Redo: make([]models.HistoryEntry, 0, len(h.redo)),
+	}
+	for i := len(h.undo) - 1; i >= 0; i-- {
+		result.Undo = append(result.Undo, h.undo[i].entry)
+	}
+	for i := len(h.redo) - 1; i >= 0; i-- {
+		result.Redo = append(result.Redo, h.redo[i].entry)
+	}
+	return result, nil
+}
+
+// step undoes or redoes the command on top of the matching stack and moves
+// it to the other one. A command whose tasks have changed since is dropped;
+// one that fails otherwise stays on its stack to be tried again.
+func (s *TaskService) step(ctx context.Context, undo bool) (*models.HistoryEntry, error) {
+	h := &s.history
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	from, to := &h.undo, &h.redo
+	verb := "undo"
+	if !undo {
+		from, to = &h.redo, &h.undo
+		verb = "redo"
+	}
+	if len(*from) == 0 {
+		return nil, domain.Conflict("nothing to %s", verb)
+	}
+
+	cmd := (*from)[len(*from)-1]
+	err := s.replay(ctx, cmd, undo, verb)
+	if err != nil && !errors.Is(err, domain.ErrConflict) {
+		return nil, err
+	}
+
+	*from = (*from)[:len(*from)-1]
+	if err != nil {
+		return nil, err
+	}
+	*to = append(*to, cmd)
+	entry := cmd.entry
+	return &entry, nil
+}
+
+// replay moves every task of a command from one side of its changes to the
+// other: from after to before when undoing, the other way round when redoing.
+// All tasks are checked first so that a stale command changes nothing, and a
+// failed write puts back the tasks already replayed.
+func (s *TaskService) replay(ctx context.Context, cmd *command, undo bool, verb string) error {
+	changes := make([]*change, len(cmd.changes))
+	copy(changes, cmd.changes)
+	if undo {
+		// Later writes may depend on earlier ones, e.g. a subtask on its parent
+		for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+			changes[i], changes[j] = changes[j], changes[i]
+		}
+	}
+
+	current := make([]*models.Task, len(changes))
+	for i, ch := range changes {
+		expected, _ := ch.sides(undo)
+		id := ch.id()
+		task, err := s.repo.GetByID(ctx, id)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		if !sameState(task, expected) {
+			return domain.Conflict("cannot %s %s: task %q has changed since", verb, cmd.entry.Action, id)
+		}
+		current[i] = task
+	}
+
+	for i, ch := range changes {
+		_, target := ch.sides(undo)
+		if err := s.restoreState(ctx, ch.id(), current[i], target); err != nil {
+			replayed := func(ch *change) *models.Task {
+				from, _ := ch.sides(undo)
+				return from
+			}
+			if revertErr := s.revert(ctx, changes[:i], replayed); revertErr != nil {
+				log.Printf("Warning: Failed to revert partial %s: %v", verb, revertErr)
+			}
+			return fmt.Errorf("failed to restore task: %w", err)
+		}
+	}
+
+	action := models.ActionRedo
+	if undo {
+		action = models.ActionUndo
+	}
+	s.audit(ctx, action, cmd.entry.TaskID, cmd.changes, undo)
+	s.publish(ctx, action, changes, undo)
+	return nil
+}
+
+// sides returns the state a change is replayed from and the one it is replayed to
+func (ch *change) sides(undo bool) (from, to *models.Task) {
+	if undo {
+		return ch.after, ch.before
+	}
+	return ch.before, ch.after
+}
+
+// id returns the ID of the task a change is about
+func (ch *change) id() string {
+	if ch.before != nil {
+		return ch.before.ID
+	}
+	return ch.after.ID
+}
+
+// restoreState writes a recorded state over the current one: it creates,
+// deletes or overwrites the task
+func (s *TaskService) restoreState(ctx context.Context, id string, current, target *models.Task) error {
+	switch {
+	case target == nil:
+		return s.repo.Delete(ctx, id)
+	case current == nil:
+		task := *target
+		return s.repo.Create(ctx, &task)
+	default:
+		task := *target
+		task.Version = current.Version
+		return s.repo.Update(ctx, &task)
+	}
+}
+
+// revert puts the tasks of changes into the states picked by state, last
+// change first; tasks already in that state are left alone
+func (s *TaskService) revert(ctx context.Context, changes []*change, state func(*change) *models.Task) error {
+	for i := len(changes) - 1; i >= 0; i-- {
+		ch := changes[i]
+		current, err := s.repo.GetByID(ctx, ch.id())
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		target := state(ch)
+		if sameState(current, target) {
+			continue
+		}
+		if err := s.restoreState(ctx, ch.id(), current, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameState reports whether two states of a task agree in everything but the
+// version, which undo and redo themselves advance; nil is a missing task
+func sameState(a, b *models.Task) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Title == b.Title &&
+		a.Description == b.Description &&
+		a.Priority == b.Priority &&
+		a.Status == b.Status &&
+		sameTime(a.DueDate, b.DueDate) &&
+		sameID(a.ParentID, b.ParentID) &&
+		sameID(a.ProjectID, b.ProjectID) &&
+		sameID(a.SeriesID, b.SeriesID) &&
+		a.Occurrence == b.Occurrence &&
+		sameRecurrence(a.Recurrence, b.Recurrence) &&
+		sameTags(a.Tags, b.Tags) &&
+		a.UpdatedAt.Equal(b.UpdatedAt) &&
+		sameTime(a.DeletedAt, b.DeletedAt)
+}
+
+// sameTime compares two optional times
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// sameRecurrence compares two optional recurrence rules
+func sameRecurrence(a, b *models.Recurrence) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
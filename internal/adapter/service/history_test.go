@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"todo-wails-go/internal/adapter/db"
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
+)
+
+// storedTask reads a task from the repository, trashed or not; nil when it does not exist
+func storedTask(t *testing.T, repo ports.TaskRepository, id string) *models.Task {
+	t.Helper()
+	task, err := repo.GetByID(context.Background(), id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("GetByID(%s): %v", id, err)
+	}
+	return task
+}
+
+// assertState fails unless got equals want in everything but the version,
+// which undo and redo advance past the one of the replaced state
+func assertState(t *testing.T, got, want *models.Task) {
+	t.Helper()
+	if got == nil || want == nil {
+		if got != want {
+			t.Fatalf("task = %+v, want %+v", got, want)
+		}
+		return
+	}
+	if got.Version <= want.Version {
+		t.Errorf("version = %d, want more than %d", got.Version, want.Version)
+	}
+	a, b := *got, *want
+	a.Version, b.Version = 0, 0
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("task = %+v, want %+v", a, b)
+	}
+}
+
+func TestUndoRedo(t *testing.T) {
+	due := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		action models.Action
+		mutate func(ctx context.Context, s ports.TaskService, task *models.Task) error
+	}{
+		{"delete", models.ActionDelete, func(ctx context.Context, s ports.TaskService, task *models.Task) error {
+			return s.DeleteTask(ctx, task.ID)
+		}},
+		{"toggle", models.ActionToggle, func(ctx context.Context, s ports.TaskService, task *models.Task) error {
+			_, err := s.ToggleTaskStatus(ctx, task.ID)
+			return err
+		}},
+		{"update", models.ActionUpdate, func(ctx context.Context, s ports.TaskService, task *models.Task) error {
+			_, err := s.UpdateTask(ctx, &models.UpdateTaskRequest{
+				ID:       task.ID,
+				Title:    "Renamed",
+				Priority: models.PriorityLow,
+				Status:   task.Status,
+				Tags:     []string{},
+				Version:  task.Version,
+			})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := db.NewMemoryRepository()
+			t.Cleanup(func() { repo.Close() })
+			s := NewTaskService(repo)
+
+			task := createTestTask(t, s, &models.CreateTaskRequest{
+				Title:       "Write report",
+				Description: "Quarterly numbers",
+				Priority:    models.PriorityHigh,
+				DueDate:     &due,
+				Tags:        []string{"work"},
+			})
+			before := storedTask(t, repo, task.ID)
+
+			// Timestamps set by the mutation must differ from the ones undo restores
+			time.Sleep(time.Millisecond)
+			if err := tt.mutate(ctx, s, before); err != nil {
+				t.Fatalf("mutation: %v", err)
+			}
+			after := storedTask(t, repo, task.ID)
+
+			entry, err := s.Undo(ctx)
+			if err != nil {
+				t.Fatalf("Undo: %v", err)
+			}
+			if entry.Action != tt.action || entry.TaskID != task.ID {
+				t.Errorf("undone %s of %s, want %s of %s", entry.Action, entry.TaskID, tt.action, task.ID)
+			}
+			undone := storedTask(t, repo, task.ID)
+			assertState(t, undone, before)
+
+			if _, err := s.Redo(ctx); err != nil {
+				t.Fatalf("Redo: %v", err)
+			}
+			assertState(t, storedTask(t, repo, task.ID), after)
+
+			history, err := s.History(ctx)
+			if err != nil {
+				t.Fatalf("History: %v", err)
+			}
+			if len(history.Undo) != 2 || len(history.Redo) != 0 || history.Undo[0].Action != tt.action {
+				t.Errorf("history = %+v, want the redone %s on top of the create", history, tt.action)
+			}
+		})
+	}
+}
+
+func TestUndoCascade(t *testing.T) {
+	ctx := context.Background()
+	repo := db.NewMemoryRepository()
+	t.Cleanup(func() { repo.Close() })
+	s := NewTaskService(repo, WithCompletePolicy(models.ChildPolicyCascade))
+
+	parent := createTestTask(t, s, &models.CreateTaskRequest{Title: "Release"})
+	child := createTestTask(t, s, &models.CreateTaskRequest{Title: "Changelog", ParentID: &parent.ID})
+	beforeParent, beforeChild := storedTask(t, repo, parent.ID), storedTask(t, repo, child.ID)
+
+	if _, err := s.ToggleTaskStatus(ctx, parent.ID); err != nil {
+		t.Fatalf("ToggleTaskStatus: %v", err)
+	}
+	if got := storedTask(t, repo, child.ID); got.Status != models.StatusCompleted {
+		t.Fatalf("child status = %v, want completed by the cascade", got.Status)
+	}
+
+	entry, err := s.Undo(ctx)
+	if err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if entry.Tasks != 2 {
+		t.Errorf("undone command changed %d tasks, want 2", entry.Tasks)
+	}
+	assertState(t, storedTask(t, repo, parent.ID), beforeParent)
+	assertState(t, storedTask(t, repo, child.ID), beforeChild)
+}
+
+func TestUndoStale(t *testing.T) {
+	ctx := context.Background()
+	repo := db.NewMemoryRepository()
+	t.Cleanup(func() { repo.Close() })
+	s := NewTaskService(repo)
+
+	task := createTestTask(t, s, &models.CreateTaskRequest{Title: "Draft"})
+	if _, err := s.ToggleTaskStatus(ctx, task.ID); err != nil {
+		t.Fatalf("ToggleTaskStatus: %v", err)
+	}
+
+	// Another client changes the task behind the history's back
+	changed := storedTask(t, repo, task.ID)
+	changed.Title = "Edited elsewhere"
+	if err := repo.Update(ctx, changed); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	changed = storedTask(t, repo, task.ID)
+
+	if _, err := s.Undo(ctx); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("Undo of a stale command: error = %v, want a conflict", err)
+	}
+	assertUnchanged(t, storedTask(t, repo, task.ID), changed)
+
+	// The stale command is dropped; the create below it is stale as well
+	history, err := s.History(ctx)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(history.Undo) != 1 || history.Undo[0].Action != models.ActionCreate {
+		t.Errorf("undo history = %+v, want only the create", history.Undo)
+	}
+	if _, err := s.Undo(ctx); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("Undo of the create: error = %v, want a conflict", err)
+	}
+	assertUnchanged(t, storedTask(t, repo, task.ID), changed)
+
+	if _, err := s.Undo(ctx); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("Undo with an empty history: error = %v, want a conflict", err)
+	}
+}
+
+func TestRedoStale(t *testing.T) {
+	ctx := context.Background()
+	repo := db.NewMemoryRepository()
+	t.Cleanup(func() { repo.Close() })
+	s := NewTaskService(repo)
+
+	task := createTestTask(t, s, &models.CreateTaskRequest{Title: "Draft"})
+	if _, err := s.ToggleTaskStatus(ctx, task.ID); err != nil {
+		t.Fatalf("ToggleTaskStatus: %v", err)
+	}
+	if _, err := s.Undo(ctx); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+
+	changed := storedTask(t, repo, task.ID)
+	changed.Priority = models.PriorityHigh
+	if err := repo.Update(ctx, changed); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if _, err := s.Redo(ctx); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("Redo of a stale command: error = %v, want a conflict", err)
+	}
+	if got := storedTask(t, repo, task.ID); got.Status != models.StatusActive {
+		t.Errorf("status = %v, want the stale redo to change nothing", got.Status)
+	}
+}
+
+// assertUnchanged fails unless a task is exactly in the given state, version included
+func assertUnchanged(t *testing.T, got, want *models.Task) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("task = %+v, want it unchanged at %+v", got, want)
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	ctx := context.Background()
+	repo := db.NewMemoryRepository()
+	t.Cleanup(func() { repo.Close() })
+	s := NewTaskService(repo)
+
+	var tasks []*models.Task
+	for i := 0; i < defaultHistoryLimit+5; i++ {
+		tasks = append(tasks, createTestTask(t, s, &models.CreateTaskRequest{Title: fmt.Sprintf("task %d", i)}))
+	}
+
+	history, err := s.History(ctx)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(history.Undo) != defaultHistoryLimit {
+		t.Fatalf("undo history holds %d commands, want %d", len(history.Undo), defaultHistoryLimit)
+	}
+	if last := history.Undo[len(history.Undo)-1]; last.TaskID != tasks[5].ID {
+		t.Errorf("oldest command creates %s, want %s", last.TaskID, tasks[5].ID)
+	}
+
+	for i := 0; i < defaultHistoryLimit; i++ {
+		if _, err := s.Undo(ctx); err != nil {
+			t.Fatalf("Undo %d: %v", i+1, err)
+		}
+	}
+	if _, err := s.Undo(ctx); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("Undo past the limit: error = %v, want a conflict", err)
+	}
+	for i, task := range tasks {
+		if exists := storedTask(t, repo, task.ID) != nil; exists != (i < 5) {
+			t.Errorf("task %d exists = %v, want %v", i, exists, i < 5)
+		}
+	}
+}
+
+// failingRepository fails to save the tasks that fail picks
+type failingRepository struct {
+	ports.TaskRepository
+	fail func(task *models.Task) bool
+}
+
+func (r *failingRepository) Create(ctx context.Context, task *models.Task) error {
+	if r.fail != nil && r.fail(task) {
+		return errors.New("disk full")
+	}
+	return r.TaskRepository.Create(ctx, task)
+}
+
+func (r *failingRepository) Update(ctx context.Context, task *models.Task) error {
+	if r.fail != nil && r.fail(task) {
+		return errors.New("disk full")
+	}
+	return r.TaskRepository.Update(ctx, task)
+}
+
+func TestFailedCommandRollsBack(t *testing.T) {
+	ctx := context.Background()
+	memory := db.NewMemoryRepository()
+	t.Cleanup(func() { memory.Close() })
+	repo := &failingRepository{TaskRepository: memory}
+	s := NewTaskService(repo, WithCompletePolicy(models.ChildPolicyCascade))
+
+	parent := createTestTask(t, s, &models.CreateTaskRequest{Title: "Release"})
+	child := createTestTask(t, s, &models.CreateTaskRequest{Title: "Changelog", ParentID: &parent.ID})
+	beforeChild := storedTask(t, memory, child.ID)
+
+	// The cascade completes the child before the parent fails to save
+	repo.fail = func(task *models.Task) bool {
+		return task.ID == parent.ID && task.Status == models.StatusCompleted
+	}
+	if _, err := s.ToggleTaskStatus(ctx, parent.ID); err == nil {
+		t.Fatal("ToggleTaskStatus succeeded, want the parent's save to fail")
+	}
+
+	got := storedTask(t, memory, child.ID)
+	if got.Status != models.StatusActive {
+		t.Errorf("child status = %v, want the failed command rolled back", got.Status)
+	}
+	assertState(t, got, beforeChild)
+
+	history, err := s.History(ctx)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(history.Undo) != 2 || history.Undo[0].Action != models.ActionCreate {
+		t.Errorf("undo history = %+v, want only the two creates", history.Undo)
+	}
+}
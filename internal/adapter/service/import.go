@@ -0,0 +1,572 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ExportTasks collects the tasks matching the filter for a backup, together
+// with the tasks in the trash that match it and every project and tag, so
+// that importing the backup restores all of them
+func (s *TaskService) ExportTasks(ctx context.Context, filter *models.FilterOptions) (*models.Backup, error) {
+	live := models.FilterOptions{}
+	if filter != nil {
+		live = *filter
+	}
+
+	tasks, err := s.repo.GetAll(ctx, &live)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+	if !live.Trashed {
+		trashed := live
+		trashed.Trashed = true
+		inTrash, err := s.repo.GetAll(ctx, &trashed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trash: %w", err)
+		}
+		tasks = append(tasks, inTrash...)
+	}
+
+	projects, err := s.repo.GetProjects(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+	tags, err := s.repo.GetTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return &models.Backup{Projects: projects, Tags: tags, Tasks: tasks}, nil
+}
+
+// ImportTasks creates tasks read from a file as one undoable command. A task
+// keeps its ID when it is a UUID; what happens to one whose ID is already
+// taken depends on the conflict strategy, which skips it by default. Subtask,
+// project and series links are kept when their target is imported too or
+// already exists, and the result lists the ones that were dropped. Projects
+// and tags missing from the store are created first; undoing the import
+// leaves them in place. An import that fails changes nothing, and a dry run
+// reports the outcome for every task without saving anything.
+func (s *TaskService) ImportTasks(ctx context.Context, data *models.Backup, opts *models.ImportOptions) (*models.ImportResult, error) {
+	if data == nil {
+		data = &models.Backup{}
+	}
+	if opts == nil {
+		opts = &models.ImportOptions{}
+	}
+	switch opts.Strategy {
+	case "", models.ConflictSkip, models.ConflictOverwrite, models.ConflictNewest, models.ConflictDuplicate:
+	default:
+		return nil, domain.Invalid("strategy", "unknown conflict strategy %q", opts.Strategy)
+	}
+
+	for i, project := range data.Projects {
+		if strings.TrimSpace(project.Name) == "" {
+			return nil, domain.Invalid("projects", "project %d: name is required", i+1)
+		}
+	}
+	for i, task := range data.Tasks {
+		if err := validateImport(task); err != nil {
+			return nil, domain.Invalid("tasks", "task %d: %v", i+1, err)
+		}
+	}
+
+	result := &models.ImportResult{DryRun: opts.DryRun}
+	created := &importCreated{}
+	if opts.DryRun {
+		if _, err := s.importBackup(ctx, data, opts, result, created); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	// A failed import rolls back the tasks it wrote and removes the projects
+	// and tags it created, so that it leaves the store as it was
+	_, err := s.record(ctx, models.ActionImport, func(ctx context.Context) (*models.Task, error) {
+		return s.importBackup(ctx, data, opts, result, created)
+	})
+	if err != nil {
+		s.discardImport(ctx, created)
+		return nil, err
+	}
+	return result, nil
+}
+
+// importCreated lists the projects and tags an import created
+type importCreated struct {
+	projects []string
+	tags     []string
+}
+
+// discardImport removes the projects and tags a failed import created
+func (s *TaskService) discardImport(ctx context.Context, created *importCreated) {
+	for _, id := range created.tags {
+		if err := s.repo.DeleteTag(ctx, id); err != nil {
+			log.Printf("Warning: Failed to remove imported tag: %v", err)
+		}
+	}
+	for _, id := range created.projects {
+		if err := s.repo.DeleteProject(ctx, id); err != nil {
+			log.Printf("Warning: Failed to remove imported project: %v", err)
+		}
+	}
+}
+
+// validateImport checks the fields an imported task brings along
+func validateImport(task *models.Task) error {
+	if strings.TrimSpace(task.Title) == "" {
+		return fmt.Errorf("title is required")
+	}
+	if utf8.RuneCountInString(task.Title) > maxTitleLength {
+		return fmt.Errorf("title must be at most %d characters", maxTitleLength)
+	}
+	for _, tag := range task.Tags {
+		if _, err := normalizeTagName(tag.Name); err != nil {
+			return err
+		}
+	}
+	if task.Priority < models.PriorityLow || task.Priority > models.PriorityHigh {
+		return fmt.Errorf("invalid priority %d", task.Priority)
+	}
+	if task.Status != models.StatusActive && task.Status != models.StatusCompleted {
+		return fmt.Errorf("invalid status %d", task.Status)
+	}
+	if task.Recurrence != nil {
+		if err := task.Recurrence.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importBackup implements ImportTasks within the recorded command and returns
+// the first task it saved
+func (s *TaskService) importBackup(ctx context.Context, data *models.Backup, opts *models.ImportOptions, result *models.ImportResult, created *importCreated) (*models.Task, error) {
+	projects, err := s.importProjects(ctx, data.Projects, opts.DryRun, result, created)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.importTags(ctx, data, opts.DryRun, result, created); err != nil {
+		return nil, err
+	}
+	return s.importTasks(ctx, data.Tasks, projects, opts, result)
+}
+
+// importProjects creates the projects of a backup that are not stored yet and
+// returns the stored project IDs by their IDs in the file. A stored project
+// with the same ID or name is used as it is; a created one keeps its ID when
+// that is a UUID.
+func (s *TaskService) importProjects(ctx context.Context, projects []*models.Project, dryRun bool, result *models.ImportResult, created *importCreated) (map[string]string, error) {
+	ids := make(map[string]string, len(projects))
+	if len(projects) == 0 {
+		return ids, nil
+	}
+
+	stored, err := s.repo.GetProjects(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+	byID := make(map[string]bool, len(stored))
+	byName := make(map[string]string, len(stored))
+	for _, project := range stored {
+		byID[project.ID] = true
+		byName[strings.ToLower(project.Name)] = project.ID
+	}
+
+	now := time.Now()
+	for _, in := range projects {
+		if byID[in.ID] {
+			ids[in.ID] = in.ID
+			continue
+		}
+		name := strings.TrimSpace(in.Name)
+		if id, ok := byName[strings.ToLower(name)]; ok {
+			ids[in.ID] = id
+			continue
+		}
+
+		project := *in
+		project.Name = name
+		project.Counts = models.TaskCounts{}
+		if _, err := uuid.Parse(in.ID); err != nil {
+			project.ID = uuid.New().String()
+		}
+		if project.CreatedAt.IsZero() {
+			project.CreatedAt = now
+		}
+		if project.UpdatedAt.IsZero() {
+			project.UpdatedAt = project.CreatedAt
+		}
+
+		ids[in.ID] = project.ID
+		byID[project.ID] = true
+		byName[strings.ToLower(name)] = project.ID
+		result.Projects++
+		if dryRun {
+			continue
+		}
+		if err := s.repo.CreateProject(ctx, &project); err != nil {
+			return nil, fmt.Errorf("failed to create project: %w", err)
+		}
+		created.projects = append(created.projects, project.ID)
+	}
+
+	return ids, nil
+}
+
+// importTags creates the tags of a backup and of its tasks that are not
+// stored yet, with the first color the file gives them; stored tags keep
+// their colors
+func (s *TaskService) importTags(ctx context.Context, data *models.Backup, dryRun bool, result *models.ImportResult, created *importCreated) error {
+	stored, err := s.repo.GetTags(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+	known := make(map[string]bool, len(stored))
+	for _, tag := range stored {
+		known[strings.ToLower(tag.Name)] = true
+	}
+
+	var missing []*models.Tag
+	byName := make(map[string]*models.Tag)
+	add := func(tag models.Tag) error {
+		name, err := normalizeTagName(tag.Name)
+		if err != nil {
+			return err
+		}
+		key := strings.ToLower(name)
+		if known[key] {
+			return nil
+		}
+		if existing, ok := byName[key]; ok {
+			if existing.Color == "" {
+				existing.Color = tag.Color
+			}
+			return nil
+		}
+		byName[key] = &models.Tag{Name: name, Color: tag.Color}
+		missing = append(missing, byName[key])
+		return nil
+	}
+
+	for _, tag := range data.Tags {
+		if err := add(*tag); err != nil {
+			return err
+		}
+	}
+	for _, task := range data.Tasks {
+		for _, tag := range task.Tags {
+			if err := add(tag); err != nil {
+				return err
+			}
+		}
+	}
+
+	result.Tags = len(missing)
+	if dryRun {
+		return nil
+	}
+	for _, tag := range missing {
+		stored, err := s.CreateTag(ctx, &models.CreateTagRequest{Name: tag.Name, Color: tag.Color})
+		if err != nil {
+			return err
+		}
+		created.tags = append(created.tags, stored.ID)
+	}
+	return nil
+}
+
+// importTasks imports the tasks of a backup once its projects are in place;
+// projects maps project IDs in the file to stored ones
+func (s *TaskService) importTasks(ctx context.Context, tasks []*models.Task, projects map[string]string, opts *models.ImportOptions, result *models.ImportResult) (*models.Task, error) {
+	var first *models.Task
+	ids := make(map[string]string)    // ID in the file -> stored ID
+	series := make(map[string]string) // series ID in the file -> new series ID
+	now := time.Now()
+
+	for _, in := range parentsFirst(tasks) {
+		existing, err := s.importTarget(ctx, in.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		task := *in
+		outcome := models.ImportCreated
+		switch {
+		case existing == nil:
+			if _, err := uuid.Parse(in.ID); err != nil {
+				task.ID = uuid.New().String()
+			}
+		case opts.Strategy == models.ConflictDuplicate:
+			task.ID = uuid.New().String()
+		case opts.Strategy == models.ConflictOverwrite,
+			opts.Strategy == models.ConflictNewest && in.UpdatedAt.After(existing.UpdatedAt):
+			outcome = models.ImportUpdated
+		default:
+			ids[in.ID] = existing.ID
+			reportImport(opts, result, existing, models.ImportSkipped, nil)
+			continue
+		}
+		if in.ID != "" {
+			ids[in.ID] = task.ID
+		}
+
+		if task.ParentID, err = s.importParent(ctx, in.ParentID, ids); err != nil {
+			return nil, err
+		}
+		if in.ParentID != nil && *in.ParentID != "" && task.ParentID == nil {
+			dropLink(result, in, "parentId", *in.ParentID)
+		}
+		if task.ProjectID, err = s.importProject(ctx, in.ProjectID, projects); err != nil {
+			return nil, err
+		}
+		if in.ProjectID != nil && *in.ProjectID != "" && task.ProjectID == nil {
+			dropLink(result, in, "projectId", *in.ProjectID)
+		}
+
+		// A dry run must not create tags, so it compares the names as given
+		if opts.DryRun {
+			task.Tags = make([]models.Tag, len(in.Tags))
+			for i, tag := range in.Tags {
+				task.Tags[i] = models.Tag{Name: tag.Name}
+			}
+		} else {
+			names := make([]string, len(in.Tags))
+			for i, tag := range in.Tags {
+				names[i] = tag.Name
+			}
+			if task.Tags, err = s.resolveTags(ctx, names); err != nil {
+				return nil, err
+			}
+		}
+
+		if outcome == models.ImportUpdated {
+			parentID := task.ParentID
+			if err := s.importOverwrite(ctx, &task, existing, opts.DryRun, now); err != nil {
+				return nil, err
+			}
+			if parentID != nil && task.ParentID == nil {
+				dropLink(result, in, "parentId", *parentID)
+			}
+			changes := diffTask(existing, &task)
+			if len(changes) == 0 {
+				reportImport(opts, result, existing, models.ImportSkipped, nil)
+				continue
+			}
+			reportImport(opts, result, &task, outcome, changes)
+		} else {
+			task.Version = 1
+			importSeries(&task, in, series)
+			if task.CreatedAt.IsZero() {
+				task.CreatedAt = now
+			}
+			if task.UpdatedAt.IsZero() {
+				task.UpdatedAt = task.CreatedAt
+			}
+			if task.Recurrence != nil {
+				startSeries(&task)
+			}
+			reported := task
+			if in.ID != task.ID {
+				reported.ID = "" // a new ID is only known once the task is saved
+			}
+			reportImport(opts, result, &reported, outcome, nil)
+		}
+		if opts.DryRun {
+			continue
+		}
+
+		if outcome == models.ImportUpdated {
+			err = s.repo.Update(ctx, &task)
+		} else {
+			err = s.repo.Create(ctx, &task)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to import task: %w", err)
+		}
+		if first == nil {
+			first = &task
+		}
+	}
+
+	return first, nil
+}
+
+// importSeries keeps a created task in the series of the file. The stored
+// tasks of that series keep the IDs from the file, so a task imported under a
+// new ID moves to a new series instead, shared with the other tasks of its
+// series that got new IDs.
+func importSeries(task, in *models.Task, series map[string]string) {
+	if in.SeriesID == nil || task.ID == in.ID {
+		return
+	}
+	id, ok := series[*in.SeriesID]
+	if !ok {
+		id = uuid.New().String()
+		series[*in.SeriesID] = id
+	}
+	task.SeriesID = &id
+}
+
+// importOverwrite turns an imported task into the replacement of the stored
+// one: it takes over the stored version and series, and a link to a parent
+// that would make a cycle is dropped
+func (s *TaskService) importOverwrite(ctx context.Context, task, existing *models.Task, dryRun bool, now time.Time) error {
+	task.Version = existing.Version
+	task.SeriesID = existing.SeriesID
+	task.Occurrence = existing.Occurrence
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = existing.CreatedAt
+	}
+	if task.UpdatedAt.IsZero() {
+		task.UpdatedAt = now
+	}
+	if task.Recurrence != nil && task.SeriesID == nil {
+		startSeries(task)
+	}
+
+	if task.ParentID == nil {
+		return nil
+	}
+	err := s.validateParent(ctx, task.ID, *task.ParentID)
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		task.ParentID = nil
+	case dryRun && errors.Is(err, domain.ErrNotFound):
+		// The parent is created by the same import
+	case err != nil:
+		return err
+	}
+	return nil
+}
+
+// importTarget returns the stored task an imported ID refers to, or nil when
+// the ID is not a UUID or is free
+func (s *TaskService) importTarget(ctx context.Context, id string) (*models.Task, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, nil
+	}
+
+	task, err := s.repo.GetByID(ctx, id)
+	switch {
+	case err == nil:
+		return task, nil
+	case errors.Is(err, domain.ErrNotFound):
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+}
+
+// reportImport counts the outcome for a task and lists it for a dry run
+func reportImport(opts *models.ImportOptions, result *models.ImportResult, task *models.Task, outcome models.ImportOutcome, changes []models.FieldChange) {
+	switch outcome {
+	case models.ImportCreated:
+		result.Created++
+	case models.ImportUpdated:
+		result.Updated++
+	default:
+		result.Skipped++
+	}
+
+	if opts.DryRun {
+		result.Items = append(result.Items, models.ImportItem{
+			ID:      task.ID,
+			Title:   task.Title,
+			Outcome: outcome,
+			Changes: changes,
+		})
+	}
+}
+
+// importParent resolves the parent of an imported task, dropping a link to
+// a task that is neither in the import nor stored outside the trash
+func (s *TaskService) importParent(ctx context.Context, parentID *string, ids map[string]string) (*string, error) {
+	if parentID == nil || *parentID == "" {
+		return nil, nil
+	}
+	if id, ok := ids[*parentID]; ok {
+		return &id, nil
+	}
+
+	_, err := s.getTask(ctx, *parentID)
+	switch {
+	case err == nil:
+		id := *parentID
+		return &id, nil
+	case errors.Is(err, domain.ErrNotFound):
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to get parent task: %w", err)
+	}
+}
+
+// importProject resolves the project of an imported task, dropping a link to
+// a project that is neither in the backup nor stored
+func (s *TaskService) importProject(ctx context.Context, projectID *string, projects map[string]string) (*string, error) {
+	if projectID == nil || *projectID == "" {
+		return nil, nil
+	}
+	if id, ok := projects[*projectID]; ok {
+		return &id, nil
+	}
+
+	id, err := s.checkProject(ctx, projectID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	return id, err
+}
+
+// dropLink reports a link of an imported task that could not be kept
+func dropLink(result *models.ImportResult, task *models.Task, field, target string) {
+	result.Dropped = append(result.Dropped, models.DroppedLink{
+		ID:     task.ID,
+		Title:  task.Title,
+		Field:  field,
+		Target: target,
+	})
+}
+
+// parentsFirst orders tasks so that a parent in the same import comes before
+// its subtasks, keeping the file order otherwise
+func parentsFirst(tasks []*models.Task) []*models.Task {
+	byID := make(map[string]*models.Task, len(tasks))
+	for _, task := range tasks {
+		if task.ID != "" {
+			byID[task.ID] = task
+		}
+	}
+
+	ordered := make([]*models.Task, 0, len(tasks))
+	visited := make(map[*models.Task]bool, len(tasks))
+	var visit func(task *models.Task)
+	visit = func(task *models.Task) {
+		if visited[task] {
+			return
+		}
+		visited[task] = true
+		if task.ParentID != nil {
+			if parent, ok := byID[*task.ParentID]; ok {
+				visit(parent)
+			}
+		}
+		ordered = append(ordered, task)
+	}
+
+	for _, task := range tasks {
+		visit(task)
+	}
+	return ordered
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"todo-wails-go/internal/adapter/db"
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+// importFixture stores one task and returns it with a copy to import over it
+func importFixture(t *testing.T) (ports.TaskService, ports.TaskRepository, *models.Task, *models.Task) {
+	t.Helper()
+	repo := db.NewMemoryRepository()
+	t.Cleanup(func() { repo.Close() })
+	s := NewTaskService(repo)
+
+	stored := createTestTask(t, s, &models.CreateTaskRequest{Title: "Stored", Priority: models.PriorityLow})
+	stored = storedTask(t, repo, stored.ID)
+	imported := *stored
+	imported.Title = "Imported"
+	imported.Priority = models.PriorityHigh
+	return s, repo, stored, &imported
+}
+
+func TestImportConflictStrategies(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  models.ConflictStrategy
+		updatedAt time.Duration // of the imported task relative to the stored one
+		want      models.ImportResult
+		wantTitle string
+	}{
+		{"default skips", "", time.Hour, models.ImportResult{Skipped: 1}, "Stored"},
+		{"skip", models.ConflictSkip, time.Hour, models.ImportResult{Skipped: 1}, "Stored"},
+		{"overwrite", models.ConflictOverwrite, -time.Hour, models.ImportResult{Updated: 1}, "Imported"},
+		{"newest with a newer task", models.ConflictNewest, time.Hour, models.ImportResult{Updated: 1}, "Imported"},
+		{"newest with an older task", models.ConflictNewest, -time.Hour, models.ImportResult{Skipped: 1}, "Stored"},
+		{"newest with the same time", models.ConflictNewest, 0, models.ImportResult{Skipped: 1}, "Stored"},
+		{"duplicate", models.ConflictDuplicate, time.Hour, models.ImportResult{Created: 1}, "Stored"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, repo, stored, imported := importFixture(t)
+			imported.UpdatedAt = stored.UpdatedAt.Add(tt.updatedAt)
+
+			result, err := s.ImportTasks(ctx, &models.Backup{Tasks: []*models.Task{imported}}, &models.ImportOptions{Strategy: tt.strategy})
+			if err != nil {
+				t.Fatalf("ImportTasks: %v", err)
+			}
+			if result.Created != tt.want.Created || result.Updated != tt.want.Updated || result.Skipped != tt.want.Skipped {
+				t.Errorf("result = %+v, want %+v", result, tt.want)
+			}
+
+			got := storedTask(t, repo, stored.ID)
+			if got.Title != tt.wantTitle {
+				t.Errorf("stored title = %q, want %q", got.Title, tt.wantTitle)
+			}
+			if tt.want.Updated == 1 && got.Version <= stored.Version {
+				t.Errorf("version = %d, want more than %d", got.Version, stored.Version)
+			}
+
+			tasks, err := s.GetTasks(ctx, nil)
+			if err != nil {
+				t.Fatalf("GetTasks: %v", err)
+			}
+			if want := 1 + tt.want.Created; len(tasks) != want {
+				t.Fatalf("%d tasks stored, want %d", len(tasks), want)
+			}
+			if tt.strategy == models.ConflictDuplicate {
+				for _, task := range tasks {
+					if task.ID != stored.ID && task.Title != "Imported" {
+						t.Errorf("duplicate = %+v, want the imported task under a new ID", task)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	ctx := context.Background()
+	s, repo, stored, imported := importFixture(t)
+	imported.Tags = []models.Tag{{Name: "new-tag", Color: "#ff0000"}}
+	project := &models.Project{ID: uuid.New().String(), Name: "New project"}
+	fresh := &models.Task{ID: uuid.New().String(), Title: "Fresh", ProjectID: &project.ID}
+	orphan := &models.Task{Title: "Orphan", ParentID: &project.ID}
+
+	data := &models.Backup{Projects: []*models.Project{project}, Tasks: []*models.Task{imported, fresh, orphan}}
+	result, err := s.ImportTasks(ctx, data, &models.ImportOptions{Strategy: models.ConflictOverwrite, DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportTasks: %v", err)
+	}
+
+	if !result.DryRun || result.Created != 2 || result.Updated != 1 || result.Projects != 1 || result.Tags != 1 {
+		t.Errorf("result = %+v, want 2 created, 1 updated, 1 project and 1 tag", result)
+	}
+	if len(result.Items) != 3 {
+		t.Fatalf("items = %+v, want one per task", result.Items)
+	}
+
+	update := result.Items[0]
+	if update.ID != stored.ID || update.Outcome != models.ImportUpdated {
+		t.Errorf("first item = %+v, want an update of %s", update, stored.ID)
+	}
+	changed := make(map[models.TaskField]models.FieldChange)
+	for _, change := range update.Changes {
+		changed[change.Field] = change
+	}
+	if change, ok := changed[models.FieldTitle]; !ok || change.From != "Stored" || change.To != "Imported" {
+		t.Errorf("changes = %+v, want the title from Stored to Imported", update.Changes)
+	}
+	if _, ok := changed[models.FieldPriority]; !ok {
+		t.Errorf("changes = %+v, want the priority", update.Changes)
+	}
+
+	if item := result.Items[1]; item.ID != fresh.ID || item.Outcome != models.ImportCreated {
+		t.Errorf("second item = %+v, want %s created under its ID", item, fresh.ID)
+	}
+	if item := result.Items[2]; item.ID != "" || item.Outcome != models.ImportCreated {
+		t.Errorf("third item = %+v, want a create under a new ID", item)
+	}
+	if len(result.Dropped) != 1 || result.Dropped[0].Field != "parentId" {
+		t.Errorf("dropped = %+v, want the parent of the orphan", result.Dropped)
+	}
+
+	// Nothing is saved
+	assertUnchanged(t, storedTask(t, repo, stored.ID), stored)
+	if task := storedTask(t, repo, fresh.ID); task != nil {
+		t.Errorf("dry run created %+v", task)
+	}
+	tags, err := s.GetTags(ctx)
+	if err != nil {
+		t.Fatalf("GetTags: %v", err)
+	}
+	projects, err := repo.GetProjects(ctx, true)
+	if err != nil {
+		t.Fatalf("GetProjects: %v", err)
+	}
+	if len(tags) != 0 || len(projects) != 0 {
+		t.Errorf("dry run created tags %+v and projects %+v", tags, projects)
+	}
+	history, err := s.History(ctx)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(history.Undo) != 1 {
+		t.Errorf("undo history = %+v, want only the create", history.Undo)
+	}
+}
+
+func TestImportKeepsLinks(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	parent := &models.Task{ID: uuid.New().String(), Title: "Parent"}
+	child := &models.Task{ID: uuid.New().String(), Title: "Child", ParentID: &parent.ID}
+	project := &models.Project{ID: "home", Name: "Home"}
+	local := &models.Task{Title: "Local", ProjectID: &project.ID}
+
+	// The child comes first in the file but is imported after its parent
+	data := &models.Backup{Projects: []*models.Project{project}, Tasks: []*models.Task{child, parent, local}}
+	result, err := s.ImportTasks(ctx, data, nil)
+	if err != nil {
+		t.Fatalf("ImportTasks: %v", err)
+	}
+	if result.Created != 3 || len(result.Dropped) != 0 {
+		t.Fatalf("result = %+v, want 3 created and no dropped links", result)
+	}
+
+	got, err := s.GetTask(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if got.ParentID == nil || *got.ParentID != parent.ID {
+		t.Errorf("child parent = %v, want %s", got.ParentID, parent.ID)
+	}
+
+	tasks, err := s.GetTasks(ctx, &models.FilterOptions{Query: "local"})
+	if err != nil {
+		t.Fatalf("GetTasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ProjectID == nil || *tasks[0].ProjectID == "home" {
+		t.Fatalf("local task = %+v, want it in the project created under a new ID", tasks)
+	}
+}
+
+func TestImportValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		task *models.Task
+	}{
+		{"blank title", &models.Task{Title: "  "}},
+		{"long title", &models.Task{Title: strings.Repeat("x", maxTitleLength+1)}},
+		{"long tag", &models.Task{Title: "Task", Tags: []models.Tag{{Name: strings.Repeat("t", maxTagNameLength+1)}}}},
+		{"bad priority", &models.Task{Title: "Task", Priority: 7}},
+		{"bad status", &models.Task{Title: "Task", Status: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newTestService(t)
+			valid := &models.Task{Title: "Valid", Tags: []models.Tag{{Name: "valid"}}}
+
+			_, err := s.ImportTasks(ctx, &models.Backup{Tasks: []*models.Task{valid, tt.task}}, nil)
+			if !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("ImportTasks error = %v, want a validation error", err)
+			}
+			tasks, err := s.GetTasks(ctx, nil)
+			if err != nil {
+				t.Fatalf("GetTasks: %v", err)
+			}
+			tags, err := s.GetTags(ctx)
+			if err != nil {
+				t.Fatalf("GetTags: %v", err)
+			}
+			if len(tasks) != 0 || len(tags) != 0 {
+				t.Errorf("rejected import stored tasks %+v and tags %+v", tasks, tags)
+			}
+		})
+	}
+}
+
+func TestFailedImportChangesNothing(t *testing.T) {
+	ctx := context.Background()
+	memory := db.NewMemoryRepository()
+	t.Cleanup(func() { memory.Close() })
+	repo := &failingRepository{TaskRepository: memory}
+	s := NewTaskService(repo)
+
+	stored := createTestTask(t, s, &models.CreateTaskRequest{Title: "Stored"})
+	before := storedTask(t, memory, stored.ID)
+	overwrite := *before
+	overwrite.Title = "Overwritten"
+
+	project := &models.Project{ID: uuid.New().String(), Name: "Imported project"}
+	data := &models.Backup{
+		Projects: []*models.Project{project},
+		Tasks: []*models.Task{
+			&overwrite,
+			{Title: "First", ProjectID: &project.ID, Tags: []models.Tag{{Name: "imported"}}},
+			{Title: "Second"},
+		},
+	}
+	repo.fail = func(task *models.Task) bool { return task.Title == "Second" }
+
+	if _, err := s.ImportTasks(ctx, data, &models.ImportOptions{Strategy: models.ConflictOverwrite}); err == nil {
+		t.Fatal("ImportTasks succeeded, want the second task to fail")
+	}
+
+	assertState(t, storedTask(t, memory, stored.ID), before)
+	tasks, err := s.GetTasks(ctx, nil)
+	if err != nil {
+		t.Fatalf("GetTasks: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Errorf("%d tasks stored, want only the one from before", len(tasks))
+	}
+	tags, err := s.GetTags(ctx)
+	if err != nil {
+		t.Fatalf("GetTags: %v", err)
+	}
+	projects, err := memory.GetProjects(ctx, true)
+	if err != nil {
+		t.Fatalf("GetProjects: %v", err)
+	}
+	if len(tags) != 0 || len(projects) != 0 {
+		t.Errorf("failed import left tags %+v and projects %+v", tags, projects)
+	}
+}
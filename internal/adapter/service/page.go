@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+)
+
+// maxPageSize bounds FilterOptions.Limit
+const maxPageSize = 500
+
+// ListTasks returns one page of the tasks matching filter together with the
+// total count and the cursor of the next page. A zero Limit returns all tasks.
+func (s *TaskService) ListTasks(ctx context.Context, filter *models.FilterOptions) (*models.TaskPage, error) {
+	if filter == nil {
+		filter = &models.FilterOptions{}
+	}
+
+	if filter.Limit < 0 || filter.Limit > maxPageSize {
+		return nil, domain.Invalid("limit", "limit must be between 0 and %d", maxPageSize)
+	}
+
+	keys, err := filter.SortKeys()
+	if err != nil {
+		return nil, domain.Invalid("sort", "%v", err)
+	}
+
+	if _, err := models.ParseCursor(filter.Cursor, keys); err != nil {
+		return nil, domain.Invalid("cursor", "%v", err)
+	}
+
+	// Fetch one extra task to learn whether another page follows
+	query := *filter
+	if query.Limit > 0 {
+		query.Limit++
+	}
+
+	tasks, err := s.repo.GetAll(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.TaskPage{Items: tasks, Total: total}
+	if filter.Limit > 0 && len(tasks) > filter.Limit {
+		page.Items = tasks[:filter.Limit]
+		page.NextCursor = models.NextCursor(keys, page.Items[filter.Limit-1])
+	}
+	if page.Items == nil {
+		page.Items = []*models.Task{}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"todo-wails-go/internal/adapter/db"
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
+)
+
+// newTestService returns a service on an empty in-memory store
+func newTestService(t *testing.T, opts ...Option) ports.TaskService {
+	t.Helper()
+	repo := db.NewMemoryRepository()
+	t.Cleanup(func() { repo.Close() })
+	return NewTaskService(repo, opts...)
+}
+
+// createTestTask creates a task or fails the test
+func createTestTask(t *testing.T, s ports.TaskService, req *models.CreateTaskRequest) *models.Task {
+	t.Helper()
+	task, err := s.CreateTask(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateTask(%q): %v", req.Title, err)
+	}
+	return task
+}
+
+func TestListTasksPages(t *testing.T) {
+	sqlite, err := db.NewSQLiteRepository(filepath.Join(t.TempDir(), "todo.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository: %v", err)
+	}
+	t.Cleanup(func() { sqlite.Close() })
+
+	repos := map[string]ports.TaskRepository{
+		"memory": db.NewMemoryRepository(),
+		"sqlite": sqlite,
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := NewTaskService(repo)
+
+			// Every third task has no due date; the others share a few
+			// dates, priorities and titles so that ties reach the ID
+			day := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+			for i := 0; i < 13; i++ {
+				req := &models.CreateTaskRequest{
+					Title:    fmt.Sprintf("task %d", i%4),
+					Priority: models.Priority(i % 3),
+				}
+				if i%3 != 0 {
+					due := day.AddDate(0, 0, i%4)
+					req.DueDate = &due
+				}
+				task := createTestTask(t, s, req)
+				if i%5 == 0 {
+					if _, err := s.ToggleTaskStatus(ctx, task.ID); err != nil {
+						t.Fatalf("ToggleTaskStatus: %v", err)
+					}
+				}
+			}
+
+			high := models.PriorityHigh
+			filters := []*models.FilterOptions{
+				{Sort: models.ParseSort("due_date")},
+				{Sort: models.ParseSort("due_date:desc")},
+				{Sort: models.ParseSort("priority:desc,due_date")},
+				{Sort: models.ParseSort("title,due_date:desc")},
+				{Sort: models.ParseSort("status,priority")},
+				{SortBy: "due_date", SortOrder: "desc", Priority: &high},
+				{},
+			}
+
+			for _, filter := range filters {
+				all, err := s.GetTasks(ctx, filter)
+				if err != nil {
+					t.Fatalf("GetTasks: %v", err)
+				}
+				if keys, _ := filter.SortKeys(); keys[0].Field == models.SortDueDate {
+					for i := 1; i < len(all); i++ {
+						if all[i-1].DueDate == nil && all[i].DueDate != nil {
+							t.Errorf("%v: task without a due date listed before one with", filter.Sort)
+						}
+					}
+				}
+
+				for _, limit := range []int{1, 4, 5} {
+					t.Run(fmt.Sprintf("%v/%d", filter.Sort, limit), func(t *testing.T) {
+						paged := listAllPages(t, s, filter, limit, len(all))
+						if len(paged) != len(all) {
+							t.Fatalf("pages hold %d tasks, want %d", len(paged), len(all))
+						}
+						seen := make(map[string]bool, len(paged))
+						for i, task := range paged {
+							if seen[task.ID] {
+								t.Errorf("task %s appears twice", task.ID)
+							}
+							seen[task.ID] = true
+							if task.ID != all[i].ID {
+								t.Errorf("task %d is %s, want %s as in the unpaged list", i, task.ID, all[i].ID)
+							}
+						}
+					})
+				}
+			}
+		})
+	}
+}
+
+// listAllPages follows the cursors of a filter and returns the tasks of all
+// pages, checking the size and the total of each page
+func listAllPages(t *testing.T, s ports.TaskService, filter *models.FilterOptions, limit, total int) []*models.Task {
+	t.Helper()
+	query := *filter
+	query.Limit = limit
+
+	var tasks []*models.Task
+	for pages := 0; ; pages++ {
+		if pages > total {
+			t.Fatalf("more than %d pages", total)
+		}
+		page, err := s.ListTasks(context.Background(), &query)
+		if err != nil {
+			t.Fatalf("ListTasks: %v", err)
+		}
+		if page.Total != total {
+			t.Errorf("Total = %d, want %d", page.Total, total)
+		}
+		if len(page.Items) > limit {
+			t.Errorf("page holds %d tasks, limit is %d", len(page.Items), limit)
+		}
+		tasks = append(tasks, page.Items...)
+		if page.NextCursor == "" {
+			return tasks
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+func TestListTasksCursorSort(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	for i := 0; i < 3; i++ {
+		createTestTask(t, s, &models.CreateTaskRequest{Title: fmt.Sprintf("task %d", i)})
+	}
+
+	page, err := s.ListTasks(ctx, &models.FilterOptions{Limit: 1, Sort: models.ParseSort("title")})
+	if err != nil {
+		t.Fatalf("ListTasks: %v", err)
+	}
+	if page.NextCursor == "" {
+		t.Fatal("first page has no next cursor")
+	}
+
+	for _, sort := range []string{"title:desc", "priority", "title,priority"} {
+		_, err := s.ListTasks(ctx, &models.FilterOptions{Limit: 1, Sort: models.ParseSort(sort), Cursor: page.NextCursor})
+		var verr *domain.ValidationError
+		if !errors.As(err, &verr) || verr.Field != "cursor" {
+			t.Errorf("cursor reused with sort %q: error = %v, want a cursor validation error", sort, err)
+		}
+	}
+
+	if _, err := s.ListTasks(ctx, &models.FilterOptions{Limit: 1, Sort: models.ParseSort("title:asc"), Cursor: page.NextCursor}); err != nil {
+		t.Errorf("cursor reused with the same sort spelled differently: %v", err)
+	}
+}
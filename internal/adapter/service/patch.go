@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+)
+
+// maxTitleLength mirrors the width of the tasks.title column
+const maxTitleLength = 255
+
+// PatchTask changes only the fields set in the request and saves only those that differ
+func (s *TaskService) PatchTask(ctx context.Context, req *models.PatchTaskRequest) (*models.Task, error) {
+	return s.record(ctx, models.ActionPatch, func(ctx context.Context) (*models.Task, error) {
+		return s.patchTask(ctx, req)
+	})
+}
+
+// patchTask implements PatchTask within the recorded command
+func (s *TaskService) patchTask(ctx context.Context, req *models.PatchTaskRequest) (*models.Task, error) {
+	if err := validatePatch(req); err != nil {
+		return nil, err
+	}
+
+	task, err := s.getTask(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if task.Version != req.Version {
+		return nil, domain.StaleVersion("task", task)
+	}
+
+	var changed []models.TaskField
+
+	if req.Title != nil && *req.Title != task.Title {
+		task.Title = *req.Title
+		changed = append(changed, models.FieldTitle)
+	}
+
+	if req.Description != nil && *req.Description != task.Description {
+		task.Description = *req.Description
+		changed = append(changed, models.FieldDescription)
+	}
+
+	if req.Priority != nil && *req.Priority != task.Priority {
+		task.Priority = *req.Priority
+		changed = append(changed, models.FieldPriority)
+	}
+
+	switch {
+	case req.ClearDueDate && task.DueDate != nil:
+		task.DueDate = nil
+		changed = append(changed, models.FieldDueDate)
+	case req.DueDate != nil && (task.DueDate == nil || !req.DueDate.Equal(*task.DueDate)):
+		task.DueDate = req.DueDate
+		changed = append(changed, models.FieldDueDate)
+	}
+
+	if req.Tags != nil {
+		tags, err := s.resolveTags(ctx, req.Tags)
+		if err != nil {
+			return nil, err
+		}
+		if !sameTags(task.Tags, tags) {
+			task.Tags = tags
+			changed = append(changed, models.FieldTags)
+		}
+	}
+
+	if req.ProjectID != nil {
+		projectID, err := s.checkProject(ctx, req.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if !sameID(task.ProjectID, projectID) {
+			task.ProjectID = projectID
+			changed = append(changed, models.FieldProjectID)
+		}
+	}
+
+	// The complete policy writes subtasks, so it runs once the references are checked
+	completing := false
+	if req.Status != nil && *req.Status != task.Status {
+		completing = *req.Status == models.StatusCompleted
+		if completing {
+			if err := s.applyCompletePolicy(ctx, task); err != nil {
+				return nil, err
+			}
+		}
+		task.Status = *req.Status
+		changed = append(changed, models.FieldStatus)
+	}
+
+	if len(changed) == 0 {
+		return task, nil
+	}
+
+	task.UpdatedAt = time.Now()
+	if err := s.repo.Patch(ctx, task, changed); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	if completing {
+		if err := s.spawnNextOccurrence(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+
+	return task, nil
+}
+
+// validateTitle trims a title and checks that it is neither blank nor longer
+// than the column
+func validateTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", domain.Required("title")
+	}
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return "", domain.Invalid("title", "title must be at most %d characters", maxTitleLength)
+	}
+	return title, nil
+}
+
+// validatePatch checks every field the patch sets and trims the title
+func validatePatch(req *models.PatchTaskRequest) error {
+	if req.ID == "" {
+		return domain.Required("id")
+	}
+
+	if req.Version == 0 {
+		return domain.Required("version")
+	}
+
+	if req.Title != nil {
+		title, err := validateTitle(*req.Title)
+		if err != nil {
+			return err
+		}
+		req.Title = &title
+	}
+
+	if req.Priority != nil && (*req.Priority < models.PriorityLow || *req.Priority > models.PriorityHigh) {
+		return domain.Invalid("priority", "invalid priority %d", *req.Priority)
+	}
+
+	if req.Status != nil && *req.Status != models.StatusActive && *req.Status != models.StatusCompleted {
+		return domain.Invalid("status", "invalid status %d", *req.Status)
+	}
+
+	if req.ClearDueDate && req.DueDate != nil {
+		return domain.Invalid("dueDate", "dueDate cannot be set and cleared at the same time")
+	}
+
+	return nil
+}
+
+// sameTags reports whether two tag lists hold the same tags, ignoring order
+func sameTags(a, b []models.Tag) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	ids := make(map[string]bool, len(a))
+	for _, tag := range a {
+		ids[tag.ID] = true
+	}
+	for _, tag := range b {
+		if !ids[tag.ID] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameID compares two optional IDs
+func sameID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
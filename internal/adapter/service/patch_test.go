@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+)
+
+func TestTitleValidation(t *testing.T) {
+	longest := strings.Repeat("я", maxTitleLength)
+	tooLong := longest + "я"
+
+	tests := []struct {
+		name  string
+		title string
+		want  string // stored title; empty for a validation error
+	}{
+		{"plain", "Plain", "Plain"},
+		{"padded", "  Padded\t", "Padded"},
+		{"longest", longest, longest},
+		{"longest padded", " " + longest + " ", longest},
+		{"empty", "", ""},
+		{"blank", " \t\n", ""},
+		{"too long", tooLong, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newTestService(t)
+			toUpdate := createTestTask(t, s, &models.CreateTaskRequest{Title: "Existing"})
+			toPatch := createTestTask(t, s, &models.CreateTaskRequest{Title: "Existing"})
+
+			created, createErr := s.CreateTask(ctx, &models.CreateTaskRequest{Title: tt.title})
+			updated, updateErr := s.UpdateTask(ctx, &models.UpdateTaskRequest{ID: toUpdate.ID, Title: tt.title, Status: toUpdate.Status, Version: toUpdate.Version})
+			title := tt.title
+			patched, patchErr := s.PatchTask(ctx, &models.PatchTaskRequest{ID: toPatch.ID, Title: &title, Version: toPatch.Version})
+
+			for op, err := range map[string]error{"create": createErr, "update": updateErr, "patch": patchErr} {
+				if tt.want == "" {
+					if !errors.Is(err, domain.ErrValidation) {
+						t.Errorf("%s error = %v, want a validation error", op, err)
+					}
+				} else if err != nil {
+					t.Errorf("%s: %v", op, err)
+				}
+			}
+			if tt.want == "" {
+				return
+			}
+
+			if created.Title != tt.want {
+				t.Errorf("created title = %q, want %q", created.Title, tt.want)
+			}
+			if updated.Title != tt.want {
+				t.Errorf("updated title = %q, want %q", updated.Title, tt.want)
+			}
+			if patched.Title != tt.want {
+				t.Errorf("patched title = %q, want %q", patched.Title, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchTrimmedTitle(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	task := createTestTask(t, s, &models.CreateTaskRequest{Title: "Title"})
+
+	padded := "  Title  "
+	patched, err := s.PatchTask(ctx, &models.PatchTaskRequest{ID: task.ID, Title: &padded, Version: task.Version})
+	if err != nil {
+		t.Fatalf("PatchTask: %v", err)
+	}
+	if patched.Title != "Title" || patched.Version != task.Version {
+		t.Errorf("patched = %q at version %d, want the padded title to change nothing", patched.Title, patched.Version)
+	}
+
+	padded = "  New title "
+	patched, err = s.PatchTask(ctx, &models.PatchTaskRequest{ID: task.ID, Title: &padded, Version: task.Version})
+	if err != nil {
+		t.Fatalf("PatchTask: %v", err)
+	}
+	stored, err := s.GetTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if patched.Title != "New title" || stored.Title != "New title" {
+		t.Errorf("title = %q, stored %q, want %q", patched.Title, stored.Title, "New title")
+	}
+}
+
+func TestPatchDueDate(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	due := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+	task := createTestTask(t, s, &models.CreateTaskRequest{Title: "Task", DueDate: &due})
+
+	later := due.Add(24 * time.Hour)
+	if _, err := s.PatchTask(ctx, &models.PatchTaskRequest{ID: task.ID, Version: task.Version, DueDate: &later, ClearDueDate: true}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("setting and clearing the due date: error = %v, want a validation error", err)
+	}
+
+	cleared, err := s.PatchTask(ctx, &models.PatchTaskRequest{ID: task.ID, Version: task.Version, ClearDueDate: true})
+	if err != nil {
+		t.Fatalf("PatchTask: %v", err)
+	}
+	if cleared.DueDate != nil || cleared.Version != task.Version+1 {
+		t.Errorf("cleared = due %v at version %d, want no due date at version %d", cleared.DueDate, cleared.Version, task.Version+1)
+	}
+
+	// Clearing a missing due date changes nothing
+	again, err := s.PatchTask(ctx, &models.PatchTaskRequest{ID: task.ID, Version: cleared.Version, ClearDueDate: true})
+	if err != nil {
+		t.Fatalf("PatchTask: %v", err)
+	}
+	if again.Version != cleared.Version || !again.UpdatedAt.Equal(cleared.UpdatedAt) {
+		t.Errorf("clearing again moved the task to version %d, want %d", again.Version, cleared.Version)
+	}
+
+	set, err := s.PatchTask(ctx, &models.PatchTaskRequest{ID: task.ID, Version: cleared.Version, DueDate: &later})
+	if err != nil {
+		t.Fatalf("PatchTask: %v", err)
+	}
+	if set.DueDate == nil || !set.DueDate.Equal(later) {
+		t.Errorf("due date = %v, want %v", set.DueDate, later)
+	}
+}
+
+func TestPatchNoOp(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	due := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+	task := createTestTask(t, s, &models.CreateTaskRequest{
+		Title:       "Task",
+		Description: "Details",
+		Priority:    models.PriorityMedium,
+		DueDate:     &due,
+		Tags:        []string{"a", "b"},
+	})
+
+	title, description := task.Title, task.Description
+	priority, status := task.Priority, task.Status
+	sameDue := due.In(time.FixedZone("UTC+3", 3*60*60))
+	patches := map[string]*models.PatchTaskRequest{
+		"empty":      {},
+		"same title": {Title: &title},
+		"same values": {
+			Title:       &title,
+			Description: &description,
+			Priority:    &priority,
+			Status:      &status,
+			DueDate:     &sameDue,
+			Tags:        []string{"B", "a"},
+		},
+	}
+
+	for name, patch := range patches {
+		t.Run(name, func(t *testing.T) {
+			patch.ID, patch.Version = task.ID, task.Version
+			got, err := s.PatchTask(ctx, patch)
+			if err != nil {
+				t.Fatalf("PatchTask: %v", err)
+			}
+			if got.Version != task.Version || !got.UpdatedAt.Equal(task.UpdatedAt) {
+				t.Errorf("task moved to version %d, want it unchanged at %d", got.Version, task.Version)
+			}
+		})
+	}
+
+	history, err := s.History(ctx)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(history.Undo) != 1 {
+		t.Errorf("undo history = %+v, want only the create", history.Undo)
+	}
+}
+
+func TestPatchStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	task := createTestTask(t, s, &models.CreateTaskRequest{Title: "Task"})
+
+	first := "First"
+	current, err := s.PatchTask(ctx, &models.PatchTaskRequest{ID: task.ID, Version: task.Version, Title: &first})
+	if err != nil {
+		t.Fatalf("PatchTask: %v", err)
+	}
+
+	second := "Second"
+	_, err = s.PatchTask(ctx, &models.PatchTaskRequest{ID: task.ID, Version: task.Version, Title: &second})
+	var conflict *domain.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("PatchTask with a stale version: error = %v, want a conflict", err)
+	}
+	if latest, ok := conflict.Current.(*models.Task); !ok || latest.Version != current.Version || latest.Title != "First" {
+		t.Errorf("conflict carries %+v, want the task at version %d", conflict.Current, current.Version)
+	}
+
+	stored, err := s.GetTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if stored.Title != "First" {
+		t.Errorf("title = %q, want the stale patch rejected", stored.Title)
+	}
+
+	if _, err := s.PatchTask(ctx, &models.PatchTaskRequest{ID: task.ID, Title: &second}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("PatchTask without a version: error = %v, want a validation error", err)
+	}
+}
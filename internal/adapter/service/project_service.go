@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+// ProjectService implements the project business logic
+type ProjectService struct {
+	repo ports.ProjectRepository
+}
+
+// NewProjectService creates a new project service
+func NewProjectService(repo ports.ProjectRepository) ports.ProjectService {
+	return &ProjectService{repo: repo}
+}
+
+// CreateProject creates a new project at the end of the list
+func (s *ProjectService) CreateProject(ctx context.Context, req *models.CreateProjectRequest) (*models.Project, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, domain.Required("name")
+	}
+
+	projects, err := s.repo.GetProjects(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	sortOrder := 0
+	for _, project := range projects {
+		if project.SortOrder >= sortOrder {
+			sortOrder = project.SortOrder + 1
+		}
+	}
+
+	now := time.Now()
+	project := &models.Project{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Color:     req.Color,
+		SortOrder: sortOrder,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repo.CreateProject(ctx, project); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return project, nil
+}
+
+// GetProject retrieves a project by ID with its task counts
+func (s *ProjectService) GetProject(ctx context.Context, id string) (*models.Project, error) {
+	if id == "" {
+		return nil, domain.Required("id")
+	}
+
+	project, err := s.repo.GetProjectByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.repo.GetProjectTaskCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tasks: %w", err)
+	}
+	project.Counts = counts[project.ID]
+
+	return project, nil
+}
+
+// GetProjects retrieves projects with their task counts
+func (s *ProjectService) GetProjects(ctx context.Context, includeArchived bool) ([]*models.Project, error) {
+	projects, err := s.repo.GetProjects(ctx, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.repo.GetProjectTaskCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	for _, project := range projects {
+		project.Counts = counts[project.ID]
+	}
+
+	return projects, nil
+}
+
+// UpdateProject updates an existing project
+func (s *ProjectService) UpdateProject(ctx context.Context, req *models.UpdateProjectRequest) (*models.Project, error) {
+	if req.ID == "" {
+		return nil, domain.Required("id")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, domain.Required("name")
+	}
+
+	project, err := s.repo.GetProjectByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	project.Name = name
+	project.Color = req.Color
+	project.Archived = req.Archived
+	project.SortOrder = req.SortOrder
+	project.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateProject(ctx, project); err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return project, nil
+}
+
+// DeleteProject deletes a project; its tasks are kept without a project
+func (s *ProjectService) DeleteProject(ctx context.Context, id string) error {
+	if id == "" {
+		return domain.Required("id")
+	}
+
+	if _, err := s.repo.GetProjectByID(ctx, id); err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return s.repo.DeleteProject(ctx, id)
+}
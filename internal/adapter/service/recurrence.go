@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// SetRecurrence sets or clears the recurrence rule of a task
+func (s *TaskService) SetRecurrence(ctx context.Context, req *models.SetRecurrenceRequest) (*models.Task, error) {
+	return s.record(ctx, models.ActionRecurrence, func(ctx context.Context) (*models.Task, error) {
+		return s.setRecurrence(ctx, req)
+	})
+}
+
+// setRecurrence implements SetRecurrence within the recorded command
+func (s *TaskService) setRecurrence(ctx context.Context, req *models.SetRecurrenceRequest) (*models.Task, error) {
+	if req.ID == "" {
+		return nil, domain.Required("id")
+	}
+
+	task, err := s.getTask(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if req.Rule == "" {
+		task.Recurrence = nil
+	} else {
+		recurrence, err := models.ParseRecurrence(req.Rule)
+		if err != nil {
+			return nil, domain.Invalid("rule", "%v", err)
+		}
+		task.Recurrence = recurrence
+		startSeries(task)
+	}
+
+	task.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return task, nil
+}
+
+// startSeries makes a task the first occurrence of its own series unless it already belongs to one
+func startSeries(task *models.Task) {
+	if task.SeriesID == nil {
+		seriesID := task.ID
+		task.SeriesID = &seriesID
+	}
+	if task.Occurrence < 1 {
+		task.Occurrence = 1
+	}
+}
+
+// spawnNextOccurrence creates the next task of a recurring series after one
+// occurrence has been completed, with the same reminder offsets. Nothing is
+// created when the series has ended
+// or the next occurrence already exists (e.g. the task was re-opened and completed again).
+func (s *TaskService) spawnNextOccurrence(ctx context.Context, task *models.Task) error {
+	if task.Recurrence == nil {
+		return nil
+	}
+
+	startSeries(task)
+
+	// Tasks without a due date recur relative to their completion
+	base := task.UpdatedAt
+	if task.DueDate != nil {
+		base = *task.DueDate
+	}
+
+	next, ok := task.Recurrence.Next(base, task.Occurrence)
+	if !ok {
+		return nil
+	}
+
+	// A later occurrence in the trash counts too; restoring it brings it back
+	for _, trashed := range []bool{false, true} {
+		existing, err := s.repo.GetAll(ctx, &models.FilterOptions{SeriesID: task.SeriesID, Trashed: trashed})
+		if err != nil {
+			return fmt.Errorf("failed to get series: %w", err)
+		}
+		for _, occurrence := range existing {
+			if occurrence.Occurrence > task.Occurrence {
+				return nil
+			}
+		}
+	}
+
+	now := time.Now()
+	recurrence := *task.Recurrence
+	seriesID := *task.SeriesID
+	nextTask := &models.Task{
+		ID:          uuid.New().String(),
+		Title:       task.Title,
+		Description: task.Description,
+		Priority:    task.Priority,
+		Status:      models.StatusActive,
+		DueDate:     &next,
+		Tags:        task.Tags,
+		ParentID:    task.ParentID,
+		ProjectID:   task.ProjectID,
+		Recurrence:  &recurrence,
+		SeriesID:    &seriesID,
+		Occurrence:  task.Occurrence + 1,
+		Version:     1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.repo.Create(ctx, nextTask); err != nil {
+		return fmt.Errorf("failed to create next occurrence: %w", err)
+	}
+
+	return s.copyReminders(ctx, task.ID, nextTask, now)
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
+
+	"github.com/google/uuid"
+)
+
+// reminderPollInterval bounds how long RunReminders sleeps, so that new
+// reminders and changed due dates are picked up
+const reminderPollInterval = time.Minute
+
+// GetReminders retrieves the reminders of a task, earliest first
+func (s *TaskService) GetReminders(ctx context.Context, taskID string) ([]*models.Reminder, error) {
+	if taskID == "" {
+		return nil, domain.Required("taskId")
+	}
+	if _, err := s.getTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetReminders(ctx, taskID)
+}
+
+// SetReminders replaces the reminders of a task. Reminders whose offset is
+// kept keep their state; new ones whose time has already passed are not
+// delivered.
+func (s *TaskService) SetReminders(ctx context.Context, req *models.SetRemindersRequest) ([]*models.Reminder, error) {
+	if req.TaskID == "" {
+		return nil, domain.Required("taskId")
+	}
+	for _, offset := range req.Offsets {
+		if offset < 0 || offset > models.MaxReminderOffset {
+			return nil, domain.Invalid("offsets", "reminder offsets must be between 0 and %d minutes", models.MaxReminderOffset)
+		}
+	}
+
+	task, err := s.getTask(ctx, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetReminders(ctx, task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reminders: %w", err)
+	}
+	byOffset := make(map[int]*models.Reminder, len(existing))
+	for _, reminder := range existing {
+		byOffset[reminder.Offset] = reminder
+	}
+
+	offsets := append([]int(nil), req.Offsets...)
+	sort.Sort(sort.Reverse(sort.IntSlice(offsets)))
+
+	now := time.Now()
+	reminders := []*models.Reminder{}
+	for i, offset := range offsets {
+		if i > 0 && offset == offsets[i-1] {
+			continue
+		}
+		if reminder, ok := byOffset[offset]; ok {
+			reminders = append(reminders, reminder)
+			continue
+		}
+
+		reminder := &models.Reminder{
+			ID:        uuid.New().String(),
+			TaskID:    task.ID,
+			Offset:    offset,
+			CreatedAt: now,
+		}
+		if task.DueDate != nil {
+			if at := reminder.FireAt(*task.DueDate); !at.After(now) {
+				reminder.FiredFor = &at
+			}
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	if err := s.repo.ReplaceReminders(ctx, task.ID, reminders); err != nil {
+		return nil, fmt.Errorf("failed to save reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+// copyReminders gives a task reminders at the offsets of another task's, in a
+// fresh state: not fired, snoozed or dismissed, unless their time has passed
+func (s *TaskService) copyReminders(ctx context.Context, fromID string, task *models.Task, now time.Time) error {
+	existing, err := s.repo.GetReminders(ctx, fromID)
+	if err != nil {
+		return fmt.Errorf("failed to get reminders: %w", err)
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+
+	reminders := make([]*models.Reminder, 0, len(existing))
+	for _, from := range existing {
+		reminder := &models.Reminder{
+			ID:        uuid.New().String(),
+			TaskID:    task.ID,
+			Offset:    from.Offset,
+			CreatedAt: now,
+		}
+		if task.DueDate != nil {
+			if at := reminder.FireAt(*task.DueDate); !at.After(now) {
+				reminder.FiredFor = &at
+			}
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	if err := s.repo.ReplaceReminders(ctx, task.ID, reminders); err != nil {
+		return fmt.Errorf("failed to save reminders: %w", err)
+	}
+	return nil
+}
+
+// SnoozeReminder makes a reminder go off again the given number of minutes from now
+func (s *TaskService) SnoozeReminder(ctx context.Context, req *models.SnoozeReminderRequest) (*models.Reminder, error) {
+	if req.ID == "" {
+		return nil, domain.Required("id")
+	}
+	if req.Minutes <= 0 || req.Minutes > models.MaxReminderOffset {
+		return nil, domain.Invalid("minutes", "snooze must be between 1 and %d minutes", models.MaxReminderOffset)
+	}
+
+	reminder, err := s.repo.GetReminder(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	until := time.Now().Add(time.Duration(req.Minutes) * time.Minute)
+	reminder.SnoozedUntil = &until
+	reminder.DismissedAt = nil
+	if err := s.repo.UpdateReminder(ctx, reminder); err != nil {
+		return nil, fmt.Errorf("failed to snooze reminder: %w", err)
+	}
+	return reminder, nil
+}
+
+// DismissReminder silences a reminder, including a pending snooze, until the
+// due date of its task moves
+func (s *TaskService) DismissReminder(ctx context.Context, id string) (*models.Reminder, error) {
+	if id == "" {
+		return nil, domain.Required("id")
+	}
+
+	reminder, err := s.repo.GetReminder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.repo.GetByID(ctx, reminder.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	reminder.SnoozedUntil = nil
+	if task.DueDate != nil {
+		if at := reminder.FireAt(*task.DueDate); reminder.Pending(*task.DueDate) {
+			reminder.FiredFor = &at
+		}
+	}
+	now := time.Now()
+	reminder.DismissedAt = &now
+
+	if err := s.repo.UpdateReminder(ctx, reminder); err != nil {
+		return nil, fmt.Errorf("failed to dismiss reminder: %w", err)
+	}
+	return reminder, nil
+}
+
+// FireDueReminders delivers every pending reminder of an active task whose
+// time has come, including those missed while nothing was running, and
+// returns when the next one is due, or the zero time when none is
+func (s *TaskService) FireDueReminders(ctx context.Context, now time.Time) (time.Time, error) {
+	reminders, err := s.repo.GetAllReminders(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get reminders: %w", err)
+	}
+
+	var next time.Time
+	tasks := make(map[string]*models.Task)
+	for _, reminder := range reminders {
+		task, ok := tasks[reminder.TaskID]
+		if !ok {
+			task, err = s.getTask(ctx, reminder.TaskID)
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return time.Time{}, err
+			}
+			tasks[reminder.TaskID] = task
+		}
+		if task == nil || task.Status != models.StatusActive || task.DueDate == nil || !reminder.Pending(*task.DueDate) {
+			continue
+		}
+
+		at := reminder.FireAt(*task.DueDate)
+		if at.After(now) {
+			if next.IsZero() || at.Before(next) {
+				next = at
+			}
+			continue
+		}
+
+		if err := s.fireReminder(ctx, task, reminder, at, now); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return next, nil
+}
+
+// fireReminder marks a reminder as delivered for the given fire time and announces it
+func (s *TaskService) fireReminder(ctx context.Context, task *models.Task, reminder *models.Reminder, at, now time.Time) error {
+	reminder.FiredFor = &at
+	reminder.DismissedAt = nil
+	if err := s.repo.UpdateReminder(ctx, reminder); err != nil {
+		return fmt.Errorf("failed to save reminder: %w", err)
+	}
+
+	if s.events != nil {
+		s.events.Publish(ctx, models.Event{
+			Type:     models.EventReminderFired,
+			TaskID:   task.ID,
+			Task:     task,
+			Reminder: reminder,
+			At:       now,
+		})
+	}
+	return nil
+}
+
+// RunReminders delivers reminders until ctx is done; it is meant to run in
+// its own goroutine. It catches up on the reminders missed while the
+// process was not running, then sleeps until the next one is due.
+func RunReminders(ctx context.Context, service ports.TaskService) {
+	for {
+		wait := reminderPollInterval
+		next, err := service.FireDueReminders(ctx, time.Now())
+		if err != nil {
+			log.Printf("Warning: Failed to deliver reminders: %v", err)
+		} else if !next.IsZero() && time.Until(next) < wait {
+			wait = time.Until(next)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+)
+
+// SearchTasks runs a full-text search over titles and descriptions. The other
+// filter fields narrow the results; snippets are built here so that every
+// repository highlights matches the same way.
+func (s *TaskService) SearchTasks(ctx context.Context, filter *models.FilterOptions) ([]*models.SearchResult, error) {
+	if filter == nil || strings.TrimSpace(filter.Query) == "" {
+		return nil, domain.Required("query")
+	}
+
+	terms := models.Tokenize(filter.Query)
+	if len(terms) == 0 {
+		return nil, domain.Invalid("query", "query must contain at least one letter or digit")
+	}
+
+	results, err := s.repo.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		result.Snippet = models.Snippet(result.Task, terms)
+	}
+
+	return results, nil
+}
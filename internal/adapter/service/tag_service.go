@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// maxTagNameLength mirrors the width of the tags.name column
+const maxTagNameLength = 64
+
+// CreateTag creates a new tag
+func (s *TaskService) CreateTag(ctx context.Context, req *models.CreateTagRequest) (*models.Tag, error) {
+	name, err := normalizeTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := &models.Tag{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Color:     req.Color,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.repo.CreateTag(ctx, tag); err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// GetTags retrieves all tags
+func (s *TaskService) GetTags(ctx context.Context) ([]*models.Tag, error) {
+	return s.repo.GetTags(ctx)
+}
+
+// UpdateTag renames or recolors a tag
+func (s *TaskService) UpdateTag(ctx context.Context, req *models.UpdateTagRequest) (*models.Tag, error) {
+	if req.ID == "" {
+		return nil, domain.Required("id")
+	}
+
+	name, err := normalizeTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := s.repo.GetTagByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	tag.Name = name
+	tag.Color = req.Color
+
+	if err := s.repo.UpdateTag(ctx, tag); err != nil {
+		return nil, fmt.Errorf("failed to update tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// DeleteTag deletes a tag and removes it from every task
+func (s *TaskService) DeleteTag(ctx context.Context, id string) error {
+	if id == "" {
+		return domain.Required("id")
+	}
+
+	if _, err := s.repo.GetTagByID(ctx, id); err != nil {
+		return fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	return s.repo.DeleteTag(ctx, id)
+}
+
+// AddTagToTask attaches a tag to a task, creating the tag if it doesn't exist yet
+func (s *TaskService) AddTagToTask(ctx context.Context, taskID, tagName string) (*models.Task, error) {
+	return s.record(ctx, models.ActionAddTag, func(ctx context.Context) (*models.Task, error) {
+		return s.addTagToTask(ctx, taskID, tagName)
+	})
+}
+
+// addTagToTask implements AddTagToTask within the recorded command
+func (s *TaskService) addTagToTask(ctx context.Context, taskID, tagName string) (*models.Task, error) {
+	if taskID == "" {
+		return nil, domain.Required("id")
+	}
+
+	task, err := s.getTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	names := make([]string, 0, len(task.Tags)+1)
+	for _, tag := range task.Tags {
+		names = append(names, tag.Name)
+	}
+	names = append(names, tagName)
+
+	tags, err := s.resolveTags(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Tags = tags
+	task.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return task, nil
+}
+
+// RemoveTagFromTask detaches a tag from a task
+func (s *TaskService) RemoveTagFromTask(ctx context.Context, taskID, tagID string) (*models.Task, error) {
+	return s.record(ctx, models.ActionRemoveTag, func(ctx context.Context) (*models.Task, error) {
+		return s.removeTagFromTask(ctx, taskID, tagID)
+	})
+}
+
+// removeTagFromTask implements RemoveTagFromTask within the recorded command
+func (s *TaskService) removeTagFromTask(ctx context.Context, taskID, tagID string) (*models.Task, error) {
+	if taskID == "" || tagID == "" {
+		return nil, domain.Required("id")
+	}
+
+	task, err := s.getTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	tags := make([]models.Tag, 0, len(task.Tags))
+	for _, tag := range task.Tags {
+		if tag.ID != tagID {
+			tags = append(tags, tag)
+		}
+	}
+
+	task.Tags = tags
+	task.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return task, nil
+}
+
+// resolveTags maps tag names to existing tags, creating the missing ones.
+// Names are matched case-insensitively and duplicates are dropped.
+func (s *TaskService) resolveTags(ctx context.Context, names []string) ([]models.Tag, error) {
+	if len(names) == 0 {
+		return []models.Tag{}, nil
+	}
+
+	existing, err := s.repo.GetTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	byName := make(map[string]*models.Tag, len(existing))
+	for _, tag := range existing {
+		byName[strings.ToLower(tag.Name)] = tag
+	}
+
+	seen := make(map[string]bool, len(names))
+	tags := make([]models.Tag, 0, len(names))
+	for _, raw := range names {
+		name, err := normalizeTagName(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		tag, ok := byName[key]
+		if !ok {
+			tag, err = s.CreateTag(ctx, &models.CreateTagRequest{Name: name})
+			if err != nil {
+				return nil, err
+			}
+			byName[key] = tag
+		}
+
+		tags = append(tags, *tag)
+	}
+
+	return tags, nil
+}
+
+// normalizeTagName trims and validates a tag name
+func normalizeTagName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", domain.Invalid("name", "tag name is required")
+	}
+	if len(name) > maxTagNameLength {
+		return "", domain.Invalid("name", "tag name must be at most %d characters", maxTagNameLength)
+	}
+	return name, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"todo-wails-go/internal/domain"
 	"todo-wails-go/internal/domain/models"
 	"todo-wails-go/internal/domain/ports"
 
@@ -13,34 +14,93 @@ import (
 
 // TaskService implements the task business logic
 type TaskService struct {
-	repo ports.TaskRepository
+	repo           ports.TaskRepository // records writes into the history while a command runs
+	completePolicy models.ChildPolicy
+	deletePolicy   models.ChildPolicy
+	trashRetention time.Duration
+	history        history
+	events         ports.EventPublisher
 }
 
 // NewTaskService creates a new task service
-func NewTaskService(repo ports.TaskRepository) ports.TaskService {
-	return &TaskService{repo: repo}
+func NewTaskService(repo ports.TaskRepository, opts ...Option) ports.TaskService {
+	s := &TaskService{
+		repo:           &recordingRepository{TaskRepository: repo},
+		completePolicy: models.ChildPolicyCascade,
+		deletePolicy:   models.ChildPolicyOrphan,
+		trashRetention: defaultTrashRetention,
+		history:        history{limit: defaultHistoryLimit},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // CreateTask creates a new task
 func (s *TaskService) CreateTask(ctx context.Context, req *models.CreateTaskRequest) (*models.Task, error) {
+	return s.record(ctx, models.ActionCreate, func(ctx context.Context) (*models.Task, error) {
+		return s.createTask(ctx, req)
+	})
+}
+
+// createTask implements CreateTask within the recorded command
+func (s *TaskService) createTask(ctx context.Context, req *models.CreateTaskRequest) (*models.Task, error) {
 	// Validate input
-	if req.Title == "" {
-		return nil, fmt.Errorf("title is required")
+	title, err := validateTitle(req.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.resolveTags(ctx, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	projectID := req.ProjectID
+	if req.ParentID != nil && *req.ParentID != "" {
+		parent, err := s.getTask(ctx, *req.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent task: %w", err)
+		}
+		// Subtasks live in their parent's project unless told otherwise
+		if projectID == nil {
+			projectID = parent.ProjectID
+		}
+	} else {
+		req.ParentID = nil
+	}
+
+	projectID, err = s.checkProject(ctx, projectID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate ID and timestamps
 	now := time.Now()
 	task := &models.Task{
 		ID:          uuid.New().String(),
-		Title:       req.Title,
+		Title:       title,
 		Description: req.Description,
 		Priority:    req.Priority,
 		Status:      models.StatusActive,
 		DueDate:     req.DueDate,
+		Tags:        tags,
+		ParentID:    req.ParentID,
+		ProjectID:   projectID,
+		Version:     1,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
 
+	if req.Recurrence != nil {
+		if err := req.Recurrence.Validate(); err != nil {
+			return nil, domain.Invalid("recurrence", "%v", err)
+		}
+		task.Recurrence = req.Recurrence
+		startSeries(task)
+	}
+
 	// Save to repository
 	if err := s.repo.Create(ctx, task); err != nil {
 		return nil, fmt.Errorf("failed to create task: %w", err)
@@ -52,10 +112,10 @@ func (s *TaskService) CreateTask(ctx context.Context, req *models.CreateTaskRequ
 // GetTask retrieves a task by ID
 func (s *TaskService) GetTask(ctx context.Context, id string) (*models.Task, error) {
 	if id == "" {
-		return nil, fmt.Errorf("id is required")
+		return nil, domain.Required("id")
 	}
 
-	return s.repo.GetByID(ctx, id)
+	return s.getTask(ctx, id)
 }
 
 // GetTasks retrieves all tasks with optional filtering
@@ -65,66 +125,144 @@ func (s *TaskService) GetTasks(ctx context.Context, filter *models.FilterOptions
 
 // UpdateTask updates an existing task
 func (s *TaskService) UpdateTask(ctx context.Context, req *models.UpdateTaskRequest) (*models.Task, error) {
+	return s.record(ctx, models.ActionUpdate, func(ctx context.Context) (*models.Task, error) {
+		return s.updateTask(ctx, req)
+	})
+}
+
+// updateTask implements UpdateTask within the recorded command
+func (s *TaskService) updateTask(ctx context.Context, req *models.UpdateTaskRequest) (*models.Task, error) {
 	// Validate input
 	if req.ID == "" {
-		return nil, fmt.Errorf("id is required")
+		return nil, domain.Required("id")
+	}
+
+	title, err := validateTitle(req.Title)
+	if err != nil {
+		return nil, err
 	}
 
-	if req.Title == "" {
-		return nil, fmt.Errorf("title is required")
+	if req.Version == 0 {
+		return nil, domain.Required("version")
 	}
 
 	// Get existing task
-	task, err := s.repo.GetByID(ctx, req.ID)
+	task, err := s.getTask(ctx, req.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
 
+	// Reject stale edits before touching subtasks; the repository repeats the
+	// check atomically when saving
+	if task.Version != req.Version {
+		return nil, domain.StaleVersion("task", task)
+	}
+
+	// Check the references before the complete policy writes any subtasks
+	var tags []models.Tag
+	if req.Tags != nil {
+		if tags, err = s.resolveTags(ctx, req.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	projectID := task.ProjectID
+	if req.ProjectID != nil {
+		if projectID, err = s.checkProject(ctx, req.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	completing := task.Status == models.StatusActive && req.Status == models.StatusCompleted
+	if completing {
+		if err := s.applyCompletePolicy(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update fields
-	task.Title = req.Title
+	task.Title = title
 	task.Description = req.Description
 	task.Priority = req.Priority
 	task.Status = req.Status
 	task.DueDate = req.DueDate
+	task.ProjectID = projectID
 	task.UpdatedAt = time.Now()
+	if req.Tags != nil {
+		task.Tags = tags
+	}
 
 	// Save changes
 	if err := s.repo.Update(ctx, task); err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
+	if completing {
+		if err := s.spawnNextOccurrence(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+
 	return task, nil
 }
 
-// DeleteTask deletes a task by ID
+// DeleteTask moves a task to the trash, where it can be restored until the trash is purged
 func (s *TaskService) DeleteTask(ctx context.Context, id string) error {
+	_, err := s.record(ctx, models.ActionDelete, func(ctx context.Context) (*models.Task, error) {
+		return s.deleteTask(ctx, id)
+	})
+	return err
+}
+
+// deleteTask implements DeleteTask within the recorded command
+func (s *TaskService) deleteTask(ctx context.Context, id string) (*models.Task, error) {
 	if id == "" {
-		return fmt.Errorf("id is required")
+		return nil, domain.Required("id")
 	}
 
 	// Check if task exists
-	_, err := s.repo.GetByID(ctx, id)
+	task, err := s.getTask(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to get task: %w", err)
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	// Subtasks trashed along with the task share its timestamp, which is how
+	// RestoreTask finds them again
+	now := time.Now()
+	if err := s.applyDeletePolicy(ctx, task, now); err != nil {
+		return nil, err
 	}
 
-	return s.repo.Delete(ctx, id)
+	if err := s.setDeletedAt(ctx, task, &now); err != nil {
+		return nil, fmt.Errorf("failed to delete task: %w", err)
+	}
+	return task, nil
 }
 
 // ToggleTaskStatus toggles the completion status of a task
 func (s *TaskService) ToggleTaskStatus(ctx context.Context, id string) (*models.Task, error) {
+	return s.record(ctx, models.ActionToggle, func(ctx context.Context) (*models.Task, error) {
+		return s.toggleTaskStatus(ctx, id)
+	})
+}
+
+// toggleTaskStatus implements ToggleTaskStatus within the recorded command
+func (s *TaskService) toggleTaskStatus(ctx context.Context, id string) (*models.Task, error) {
 	if id == "" {
-		return nil, fmt.Errorf("id is required")
+		return nil, domain.Required("id")
 	}
 
 	// Get existing task
-	task, err := s.repo.GetByID(ctx, id)
+	task, err := s.getTask(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
 
 	// Toggle status
 	if task.Status == models.StatusActive {
+		if err := s.applyCompletePolicy(ctx, task); err != nil {
+			return nil, err
+		}
 		task.Status = models.StatusCompleted
 	} else {
 		task.Status = models.StatusActive
@@ -137,5 +275,24 @@ func (s *TaskService) ToggleTaskStatus(ctx context.Context, id string) (*models.
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
+	if task.Status == models.StatusCompleted {
+		if err := s.spawnNextOccurrence(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+
 	return task, nil
 }
+
+// checkProject verifies that a referenced project exists; "" is normalized to no project
+func (s *TaskService) checkProject(ctx context.Context, projectID *string) (*string, error) {
+	if projectID == nil || *projectID == "" {
+		return nil, nil
+	}
+
+	if _, err := s.repo.GetProjectByID(ctx, *projectID); err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return projectID, nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
+)
+
+// defaultTrashRetention is how long deleted tasks stay restorable
+const defaultTrashRetention = 30 * 24 * time.Hour
+
+// WithTrashRetention sets how long deleted tasks stay in the trash before
+// PurgeExpiredTrash removes them for good; non-positive values keep the default
+func WithTrashRetention(retention time.Duration) Option {
+	return func(s *TaskService) {
+		if retention > 0 {
+			s.trashRetention = retention
+		}
+	}
+}
+
+// trashRetentionFromEnv reads the retention in days from TODO_TRASH_RETENTION_DAYS
+func trashRetentionFromEnv() Option {
+	days, _ := strconv.Atoi(os.Getenv("TODO_TRASH_RETENTION_DAYS"))
+	return WithTrashRetention(time.Duration(days) * 24 * time.Hour)
+}
+
+// getTask retrieves a task that is not in the trash; trashed tasks are not found
+func (s *TaskService) getTask(ctx context.Context, id string) (*models.Task, error) {
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task.DeletedAt != nil {
+		return nil, domain.NotFound("task")
+	}
+	return task, nil
+}
+
+// liveDescendants retrieves the descendants of a task that are not in the trash
+func (s *TaskService) liveDescendants(ctx context.Context, id string) ([]*models.Task, error) {
+	descendants, err := s.repo.GetDescendants(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	live := descendants[:0]
+	for _, task := range descendants {
+		if task.DeletedAt == nil {
+			live = append(live, task)
+		}
+	}
+	return live, nil
+}
+
+// setDeletedAt moves a task into the trash, or out of it for nil, leaving UpdatedAt alone
+func (s *TaskService) setDeletedAt(ctx context.Context, task *models.Task, deletedAt *time.Time) error {
+	task.DeletedAt = deletedAt
+	return s.repo.Patch(ctx, task, []models.TaskField{models.FieldDeletedAt})
+}
+
+// ListTrash retrieves the tasks in the trash, most recently deleted first
+func (s *TaskService) ListTrash(ctx context.Context) ([]*models.Task, error) {
+	tasks, err := s.repo.GetAll(ctx, &models.FilterOptions{
+		Trashed: true,
+		Sort:    []models.SortKey{{Field: models.SortCreatedAt, Direction: models.SortDesc}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].DeletedAt.After(*tasks[j].DeletedAt)
+	})
+	return tasks, nil
+}
+
+// RestoreTask takes a task out of the trash together with the subtasks that
+// were deleted along with it
+func (s *TaskService) RestoreTask(ctx context.Context, id string) (*models.Task, error) {
+	return s.record(ctx, models.ActionRestore, func(ctx context.Context) (*models.Task, error) {
+		return s.restoreTask(ctx, id)
+	})
+}
+
+// restoreTask implements RestoreTask within the recorded command
+func (s *TaskService) restoreTask(ctx context.Context, id string) (*models.Task, error) {
+	if id == "" {
+		return nil, domain.Required("id")
+	}
+
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	if task.DeletedAt == nil {
+		return nil, domain.Conflict("task is not in the trash")
+	}
+
+	if task.ParentID != nil {
+		parent, err := s.repo.GetByID(ctx, *task.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent task: %w", err)
+		}
+		if parent.DeletedAt != nil {
+			return nil, domain.Conflict("parent task is in the trash, restore it first")
+		}
+	}
+
+	descendants, err := s.repo.GetDescendants(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	deletedAt := *task.DeletedAt
+	if err := s.setDeletedAt(ctx, task, nil); err != nil {
+		return nil, fmt.Errorf("failed to restore task: %w", err)
+	}
+
+	// Subtasks trashed on their own before the parent stay in the trash;
+	// descendants come parents first, so restored subtrees stay connected
+	for _, child := range descendants {
+		if child.DeletedAt == nil || !child.DeletedAt.Equal(deletedAt) {
+			continue
+		}
+		if err := s.setDeletedAt(ctx, child, nil); err != nil {
+			return nil, fmt.Errorf("failed to restore subtask: %w", err)
+		}
+	}
+
+	return task, nil
+}
+
+// EmptyTrash permanently deletes every task in the trash and returns how many were deleted
+func (s *TaskService) EmptyTrash(ctx context.Context) (int, error) {
+	return s.purgeTrash(ctx, time.Now())
+}
+
+// PurgeExpiredTrash permanently deletes the tasks that have been in the trash
+// longer than the retention period
+func (s *TaskService) PurgeExpiredTrash(ctx context.Context) (int, error) {
+	return s.purgeTrash(ctx, time.Now().Add(-s.trashRetention))
+}
+
+// purgeTrash permanently deletes the tasks trashed at or before the given
+// time. Purging cannot be undone, so it bypasses the undo history, but every
+// purged task still gets an audit event and a deletion event.
+func (s *TaskService) purgeTrash(ctx context.Context, before time.Time) (int, error) {
+	trashed, err := s.repo.GetAll(ctx, &models.FilterOptions{Trashed: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get trash: %w", err)
+	}
+
+	n, err := s.repo.PurgeTrash(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	var changes []*change
+	for _, task := range trashed {
+		if !task.DeletedAt.After(before) {
+			changes = append(changes, &change{before: task})
+		}
+	}
+	s.audit(ctx, models.ActionPurge, "", changes, false)
+	s.publish(ctx, models.ActionPurge, changes, false)
+
+	return n, nil
+}
+
+// RunTrashPurge purges expired trash right away and then at every interval
+// until ctx is done; it is meant to run in its own goroutine
+func RunTrashPurge(ctx context.Context, service ports.TaskService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := service.PurgeExpiredTrash(ctx)
+		if err != nil {
+			log.Printf("Warning: Failed to purge trash: %v", err)
+		} else if n > 0 {
+			log.Printf("Purged %d tasks from the trash", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
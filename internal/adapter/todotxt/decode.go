@@ -0,0 +1,146 @@
+package todotxt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// Decode reads one task per non-blank line. A "+project" is looked up by
+// name among the projects, ignoring case; the first one found becomes the
+// task's project.
+func Decode(r io.Reader, projects []*models.Project) ([]*models.Task, error) {
+	byName := make(map[string]string, len(projects))
+	for _, project := range projects {
+		byName[strings.ToLower(word(project.Name))] = project.ID
+	}
+
+	var tasks []*models.Task
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if text == "" {
+			continue
+		}
+
+		task, err := decodeTask(text, byName)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// decodeTask parses a todo.txt line; projects maps lower-case project names
+// to IDs
+func decodeTask(text string, projects map[string]string) (*models.Task, error) {
+	task := &models.Task{Priority: models.PriorityLow, Status: models.StatusActive}
+	fields := strings.Fields(text)
+
+	// The prefix: "x" and the completion date, the priority, the creation date
+	if fields[0] == "x" {
+		task.Status = models.StatusCompleted
+		fields = fields[1:]
+		if len(fields) > 0 {
+			if completed, ok := parseDate(fields[0]); ok {
+				task.UpdatedAt = completed
+				fields = fields[1:]
+			}
+		}
+	}
+	prioritized := false
+	if len(fields) > 0 {
+		if letter, ok := priorityLetter(fields[0]); ok {
+			task.Priority = decodePriority(letter)
+			prioritized = true
+			fields = fields[1:]
+		}
+	}
+	if len(fields) > 0 {
+		if created, ok := parseDate(fields[0]); ok {
+			task.CreatedAt = created
+			fields = fields[1:]
+		}
+	}
+	if task.CreatedAt.IsZero() {
+		// A task is not completed before it was created
+		task.CreatedAt = task.UpdatedAt
+	}
+
+	var title []string
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		switch {
+		case len(field) > 1 && field[0] == '+':
+			if id, ok := projects[strings.ToLower(field[1:])]; ok && task.ProjectID == nil {
+				task.ProjectID = &id
+				continue
+			}
+		case len(field) > 1 && field[0] == '@':
+			if key := strings.ToLower(field[1:]); !seen[key] {
+				seen[key] = true
+				task.Tags = append(task.Tags, models.Tag{Name: field[1:]})
+			}
+			continue
+		case strings.HasPrefix(field, "due:"):
+			due, ok := parseDate(strings.TrimPrefix(field, "due:"))
+			if !ok {
+				return nil, fmt.Errorf("invalid due date %q", field)
+			}
+			task.DueDate = &due
+			continue
+		case strings.HasPrefix(field, "pri:") && !prioritized:
+			if letter, ok := priorityLetter("(" + strings.TrimPrefix(field, "pri:") + ")"); ok {
+				task.Priority = decodePriority(letter)
+				prioritized = true
+				continue
+			}
+		}
+		title = append(title, field)
+	}
+
+	task.Title = strings.Join(title, " ")
+	if task.Title == "" {
+		return nil, fmt.Errorf("task has no text")
+	}
+	return task, nil
+}
+
+// priorityLetter reads a priority such as "(A)"
+func priorityLetter(field string) (byte, bool) {
+	if len(field) != 3 || field[0] != '(' || field[2] != ')' || field[1] < 'A' || field[1] > 'Z' {
+		return 0, false
+	}
+	return field[1], true
+}
+
+// decodePriority maps a priority letter onto a priority: A is high, B is
+// medium and the rest is low
+func decodePriority(letter byte) models.Priority {
+	switch letter {
+	case 'A':
+		return models.PriorityHigh
+	case 'B':
+		return models.PriorityMedium
+	default:
+		return models.PriorityLow
+	}
+}
+
+// parseDate reads a date as local midnight
+func parseDate(value string) (time.Time, bool) {
+	t, err := time.ParseInLocation(dateLayout, value, time.Local)
+	return t, err == nil
+}
@@ -0,0 +1,106 @@
+// Package todotxt reads and writes tasks in the todo.txt format, one task
+// per line:
+//
+//	x 2026-03-02 2026-03-01 Call the landlord +Home @phone due:2026-03-05
+//	(A) 2026-03-01 Renew passport +Admin @town
+//
+// A leading "x" marks a completed task, followed by the completion date,
+// which maps to UpdatedAt. The priority "(A)" is High, "(B)" Medium and any
+// other letter or none is Low; completed tasks keep theirs as "pri:A". The
+// creation date maps to CreatedAt, "+project" to the project of that name,
+// "@context" to a tag and "due:" to DueDate. Everything else, including
+// unknown key:value pairs such as "t:2026-03-01" and projects that do not
+// exist, stays in the title, so it survives an import and export unchanged.
+// Names with spaces are written with "-" between their words. A project is
+// still found under such a name, but a tag "code review" comes back from a
+// round trip as "code-review".
+//
+// Descriptions, subtasks and recurrence have no place in a todo.txt line
+// and are not written.
+package todotxt
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// dateLayout is the form of every date in a todo.txt line
+const dateLayout = "2006-01-02"
+
+// Encode writes one line per task. Projects name the projects the tasks may
+// belong to; a task in a project that is not listed is written without one.
+func Encode(w io.Writer, tasks []*models.Task, projects []*models.Project) error {
+	names := make(map[string]string, len(projects))
+	for _, project := range projects {
+		names[project.ID] = word(project.Name)
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, task := range tasks {
+		if _, err := bw.WriteString(encodeTask(task, names) + "\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// encodeTask formats a task as a todo.txt line
+func encodeTask(task *models.Task, projects map[string]string) string {
+	var parts []string
+	completed := task.Status == models.StatusCompleted
+
+	if completed {
+		parts = append(parts, "x", formatDate(task.UpdatedAt))
+	} else if letter := encodePriority(task.Priority); letter != "" {
+		parts = append(parts, "("+letter+")")
+	}
+	if !task.CreatedAt.IsZero() {
+		parts = append(parts, formatDate(task.CreatedAt))
+	}
+
+	parts = append(parts, strings.Fields(task.Title)...)
+
+	if task.ProjectID != nil {
+		if name := projects[*task.ProjectID]; name != "" {
+			parts = append(parts, "+"+name)
+		}
+	}
+	for _, tag := range task.Tags {
+		parts = append(parts, "@"+word(tag.Name))
+	}
+	if task.DueDate != nil {
+		parts = append(parts, "due:"+formatDate(*task.DueDate))
+	}
+	if letter := encodePriority(task.Priority); completed && letter != "" {
+		parts = append(parts, "pri:"+letter)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// encodePriority returns the letter of a priority; Low has none
+func encodePriority(priority models.Priority) string {
+	switch priority {
+	case models.PriorityHigh:
+		return "A"
+	case models.PriorityMedium:
+		return "B"
+	default:
+		return ""
+	}
+}
+
+// formatDate writes the local date of a time
+func formatDate(t time.Time) string {
+	return t.Local().Format(dateLayout)
+}
+
+// word turns a name into a single word for a "+project" or "@context",
+// joining the words of a name that has spaces with "-"
+func word(name string) string {
+	return strings.Join(strings.Fields(name), "-")
+}
@@ -0,0 +1,215 @@
+package todotxt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// testProjects are the stored projects the tests refer to by name
+var testProjects = []*models.Project{
+	{ID: "p-home", Name: "Home"},
+	{ID: "p-paper", Name: "Paper Work"},
+}
+
+// day returns local midnight of a day in March 2026
+func day(n int) time.Time {
+	return time.Date(2026, 3, n, 0, 0, 0, 0, time.Local)
+}
+
+// tagNames lists the names of tags
+func tagNames(tags []models.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+func TestDecode(t *testing.T) {
+	home, paper := "p-home", "p-paper"
+	due := day(5)
+
+	tests := []struct {
+		line string
+		want models.Task
+		tags []string
+	}{
+		{
+			"x 2026-03-02 2026-03-01 Call the landlord +Home @phone due:2026-03-05",
+			models.Task{Title: "Call the landlord", Status: models.StatusCompleted, UpdatedAt: day(2), CreatedAt: day(1), ProjectID: &home, DueDate: &due},
+			[]string{"phone"},
+		},
+		{"(A) Renew passport", models.Task{Title: "Renew passport", Priority: models.PriorityHigh}, nil},
+		{"(B) 2026-03-01 Pay rent", models.Task{Title: "Pay rent", Priority: models.PriorityMedium, CreatedAt: day(1)}, nil},
+		{"(C) Water plants", models.Task{Title: "Water plants"}, nil},
+		{"(a) lower case is text", models.Task{Title: "(a) lower case is text"}, nil},
+		{
+			"x 2026-03-02 Sort mail pri:A",
+			models.Task{Title: "Sort mail", Status: models.StatusCompleted, Priority: models.PriorityHigh, UpdatedAt: day(2), CreatedAt: day(2)},
+			nil,
+		},
+		{"x Sort mail pri:B", models.Task{Title: "Sort mail", Status: models.StatusCompleted, Priority: models.PriorityMedium}, nil},
+		{"(A) Sort mail pri:B", models.Task{Title: "Sort mail pri:B", Priority: models.PriorityHigh}, nil},
+		{"Sort mail pri:high", models.Task{Title: "Sort mail pri:high"}, nil},
+		{"Read book t:2026-03-01 rec:1w", models.Task{Title: "Read book t:2026-03-01 rec:1w"}, nil},
+		{"Plan trip +Unknown +home +Paper-Work", models.Task{Title: "Plan trip +Unknown +Paper-Work", ProjectID: &home}, nil},
+		{"File taxes +paper-work", models.Task{Title: "File taxes", ProjectID: &paper}, nil},
+		{"@Work Call Bob @work @home", models.Task{Title: "Call Bob"}, []string{"Work", "home"}},
+		{"Email x (A) 2026-03-01 Bob", models.Task{Title: "Email x (A) 2026-03-01 Bob"}, nil},
+		{"Lone + and @ signs", models.Task{Title: "Lone + and @ signs"}, nil},
+		{"\ufeff  Padded   words  ", models.Task{Title: "Padded words"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			tasks, err := Decode(strings.NewReader(tt.line), testProjects)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if len(tasks) != 1 {
+				t.Fatalf("decoded %d tasks, want 1", len(tasks))
+			}
+			got := tasks[0]
+			if names := tagNames(got.Tags); len(names) != 0 || len(tt.tags) != 0 {
+				if !reflect.DeepEqual(names, tt.tags) {
+					t.Errorf("tags = %v, want %v", names, tt.tags)
+				}
+			}
+			got.Tags = nil
+
+			want := tt.want
+			if want.Status == 0 {
+				want.Status = models.StatusActive
+			}
+			if !reflect.DeepEqual(*got, want) {
+				t.Errorf("task = %+v, want %+v", *got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Pay rent due:tomorrow", `line 1: invalid due date "due:tomorrow"`},
+		{"Pay rent\n\n(A) @home +Home", "line 3: task has no text"},
+		{"x 2026-03-02", "line 1: task has no text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(tt.input), testProjects)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Decode error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	home, paper, gone := "p-home", "p-paper", "p-gone"
+	due := day(5).Add(15 * time.Hour)
+
+	tests := []struct {
+		name string
+		task *models.Task
+		want string
+	}{
+		{
+			"active with everything",
+			&models.Task{Title: "Renew passport", Priority: models.PriorityHigh, CreatedAt: day(1), ProjectID: &paper, DueDate: &due,
+				Tags: []models.Tag{{Name: "town"}, {Name: "code review"}}},
+			"(A) 2026-03-01 Renew passport +Paper-Work @town @code-review due:2026-03-05",
+		},
+		{
+			"completed keeps its priority",
+			&models.Task{Title: "Call", Priority: models.PriorityMedium, Status: models.StatusCompleted, CreatedAt: day(1), UpdatedAt: day(2).Add(time.Hour), ProjectID: &home},
+			"x 2026-03-02 2026-03-01 Call +Home pri:B",
+		},
+		{"low has no priority", &models.Task{Title: "Water  plants\tsoon"}, "Water plants soon"},
+		{"unknown project", &models.Task{Title: "Orphan", ProjectID: &gone}, "Orphan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := Encode(&b, []*models.Task{tt.task}, testProjects); err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			if got := b.String(); got != tt.want+"\n" {
+				t.Errorf("Encode = %q, want %q", got, tt.want+"\n")
+			}
+		})
+	}
+}
+
+func TestLineRoundTrip(t *testing.T) {
+	// Lines in the order Encode writes their parts come back unchanged
+	lines := []string{
+		"x 2026-03-02 2026-03-01 Call the landlord +Home @phone due:2026-03-05",
+		"x 2026-03-02 2026-03-01 Sort mail pri:A",
+		"(A) 2026-03-01 Renew passport +Paper-Work @town",
+		"(B) Read book t:2026-03-01 rec:1w +Unknown @reading due:2026-03-10",
+		"2026-03-01 Water plants",
+	}
+	input := strings.Join(lines, "\n") + "\n"
+
+	tasks, err := Decode(strings.NewReader(input), testProjects)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	var b strings.Builder
+	if err := Encode(&b, tasks, testProjects); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if got := b.String(); got != input {
+		t.Errorf("round trip =\n%s\nwant\n%s", got, input)
+	}
+}
+
+func TestTaskRoundTrip(t *testing.T) {
+	home := "p-home"
+	due := day(5)
+	tasks := []*models.Task{
+		{Title: "Call the landlord", Priority: models.PriorityHigh, Status: models.StatusActive, CreatedAt: day(1), ProjectID: &home, DueDate: &due,
+			Tags: []models.Tag{{Name: "phone"}, {Name: "code review"}}},
+		{Title: "Pay rent", Priority: models.PriorityMedium, Status: models.StatusCompleted, CreatedAt: day(1), UpdatedAt: day(3)},
+		{Title: "Someday", Priority: models.PriorityLow, Status: models.StatusActive, CreatedAt: day(2)},
+	}
+
+	var b strings.Builder
+	if err := Encode(&b, tasks, testProjects); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	decoded, err := Decode(strings.NewReader(b.String()), testProjects)
+	if err != nil {
+		t.Fatalf("Decode: %v\n%s", err, b.String())
+	}
+	if len(decoded) != len(tasks) {
+		t.Fatalf("decoded %d tasks, want %d", len(decoded), len(tasks))
+	}
+
+	for i, got := range decoded {
+		want := *tasks[i]
+		// Tag names with spaces come back hyphenated
+		wantTags := tagNames(want.Tags)
+		for j, name := range wantTags {
+			wantTags[j] = strings.ReplaceAll(name, " ", "-")
+		}
+		if names := tagNames(got.Tags); len(names) != 0 || len(wantTags) != 0 {
+			if !reflect.DeepEqual(names, wantTags) {
+				t.Errorf("task %d: tags = %v, want %v", i, names, wantTags)
+			}
+		}
+		got.Tags, want.Tags = nil, nil
+		if !reflect.DeepEqual(*got, want) {
+			t.Errorf("task %d = %+v, want %+v", i, *got, want)
+		}
+	}
+}
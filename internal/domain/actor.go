@@ -0,0 +1,17 @@
+package domain
+
+import "context"
+
+// actorKey carries the name of whoever makes a change
+type actorKey struct{}
+
+// WithActor returns a context attributing the changes made with it to actor
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// Actor returns the name attached by WithActor, or "" when there is none
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
@@ -0,0 +1,131 @@
+// Package domain holds the errors and request context shared by every layer of the application.
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound is returned when a task, tag or project does not exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict is returned when a change clashes with the current state,
+	// such as a duplicate name or a task that still has subtasks
+	ErrConflict = errors.New("conflict")
+
+	// ErrValidation matches every *ValidationError via errors.Is
+	ErrValidation = errors.New("validation failed")
+)
+
+// Stable error codes for clients
+const (
+	CodeNotFound   = "not_found"
+	CodeValidation = "validation_error"
+	CodeConflict   = "conflict"
+	CodeInternal   = "internal"
+)
+
+// ValidationError reports invalid input, naming the offending request field
+type ValidationError struct {
+	Field    string
+	Message  string
+	Position int // 1-based character position within the field's value, 0 when not applicable
+}
+
+// Error implements error
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Is makes errors.Is(err, ErrValidation) true
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Invalid creates a ValidationError for a field
+func Invalid(field, format string, args ...interface{}) error {
+	return &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// Required creates the ValidationError for a missing field
+func Required(field string) error {
+	return Invalid(field, "%s is required", field)
+}
+
+// NotFound reports a missing entity, e.g. "task not found"
+func NotFound(entity string) error {
+	return fmt.Errorf("%s %w", entity, ErrNotFound)
+}
+
+// Conflict reports a change that clashes with the current state
+func Conflict(format string, args ...interface{}) error {
+	return &ConflictError{Message: fmt.Sprintf(format, args...)}
+}
+
+// ConflictError is a conflict, optionally carrying the current server-side
+// state of the entity so clients can merge or retry without another round trip
+type ConflictError struct {
+	Message string
+	Current interface{}
+}
+
+// Error implements error
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+// Is makes errors.Is(err, ErrConflict) true
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// StaleVersion reports an optimistic concurrency failure: the entity was
+// changed since the client read it, and current is its latest state
+func StaleVersion(entity string, current interface{}) error {
+	return &ConflictError{
+		Message: fmt.Sprintf("%s was modified by someone else, reload and try again", entity),
+		Current: current,
+	}
+}
+
+// Code classifies an error into one of the stable error codes
+func Code(err error) string {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, ErrValidation):
+		return CodeValidation
+	case errors.Is(err, ErrConflict):
+		return CodeConflict
+	default:
+		return CodeInternal
+	}
+}
+
+// Field returns the request field named by a ValidationError in err's chain
+func Field(err error) string {
+	var validation *ValidationError
+	if errors.As(err, &validation) {
+		return validation.Field
+	}
+	return ""
+}
+
+// Current returns the server state carried by a ConflictError in err's chain
+func Current(err error) interface{} {
+	var conflict *ConflictError
+	if errors.As(err, &conflict) {
+		return conflict.Current
+	}
+	return nil
+}
+
+// Position returns the position carried by a ValidationError in err's chain
+func Position(err error) int {
+	var validation *ValidationError
+	if errors.As(err, &validation) {
+		return validation.Position
+	}
+	return 0
+}
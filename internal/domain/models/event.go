@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// EventType names a domain event; the names double as Wails runtime event names
+type EventType string
+
+const (
+	EventTaskCreated       EventType = "task:created"
+	EventTaskUpdated       EventType = "task:updated"
+	EventTaskDeleted       EventType = "task:deleted"
+	EventTaskStatusChanged EventType = "task:statusChanged"
+	EventReminderFired     EventType = "reminder:fired"
+)
+
+// Event announces a task change after it has been saved, or a reminder that went off
+type Event struct {
+	Type     EventType `json:"type"`
+	TaskID   string    `json:"taskId"`
+	Task     *Task     `json:"task"`             // the task after the change, or its last state when deleted for good
+	Action   Action    `json:"action,omitempty"` // the mutation that caused the change
+	Actor    string    `json:"actor,omitempty"`
+	Reminder *Reminder `json:"reminder,omitempty"` // for reminder:fired
+	At       time.Time `json:"at"`
+}
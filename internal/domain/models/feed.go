@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Feed is a read-only calendar subscription to the tasks matching a saved
+// filter. Anyone who knows the token can read the feed, so it is only
+// returned when the feed is created.
+type Feed struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Token     string        `json:"token,omitempty"` // secret part of the feed URL
+	Filter    FilterOptions `json:"filter"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// CreateFeedRequest represents request to create a feed
+type CreateFeedRequest struct {
+	Name   string        `json:"name"`
+	Filter FilterOptions `json:"filter"` // Limit and Cursor are ignored
+}
@@ -0,0 +1,35 @@
+package models
+
+// ChildPolicy decides what happens to subtasks when their parent is completed or deleted
+type ChildPolicy string
+
+const (
+	// ChildPolicyCascade applies the same operation to every descendant
+	ChildPolicyCascade ChildPolicy = "cascade"
+	// ChildPolicyBlock rejects the operation while the parent still has (active) subtasks
+	ChildPolicyBlock ChildPolicy = "block"
+	// ChildPolicyOrphan detaches the affected subtasks, turning them into top-level tasks
+	ChildPolicyOrphan ChildPolicy = "orphan"
+)
+
+// Valid reports whether the policy is one of the known values
+func (p ChildPolicy) Valid() bool {
+	switch p {
+	case ChildPolicyCascade, ChildPolicyBlock, ChildPolicyOrphan:
+		return true
+	}
+	return false
+}
+
+// TaskNode is a task together with its subtasks and rolled-up completion
+type TaskNode struct {
+	Task     *Task       `json:"task"`
+	Children []*TaskNode `json:"children"`
+	Progress float64     `json:"progress"` // completion percentage, 0-100
+}
+
+// MoveTaskRequest represents request to re-parent a task; an empty ParentID moves it to the top level
+type MoveTaskRequest struct {
+	ID       string `json:"id"`
+	ParentID string `json:"parentId"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// Action names a task mutation in the undo history
+type Action string
+
+const (
+	ActionCreate     Action = "create"
+	ActionUpdate     Action = "update"
+	ActionPatch      Action = "patch"
+	ActionDelete     Action = "delete"
+	ActionToggle     Action = "toggle"
+	ActionMove       Action = "move"
+	ActionRecurrence Action = "recurrence"
+	ActionAddTag     Action = "addTag"
+	ActionRemoveTag  Action = "removeTag"
+	ActionRestore    Action = "restore"
+	ActionPurge      Action = "purge"
+	ActionImport     Action = "import"
+	ActionUndo       Action = "undo"
+	ActionRedo       Action = "redo"
+)
+
+// HistoryEntry describes one undoable task mutation
+type HistoryEntry struct {
+	Action Action    `json:"action"`
+	TaskID string    `json:"taskId"` // the task the mutation was aimed at
+	Title  string    `json:"title"`
+	Tasks  int       `json:"tasks"` // tasks changed, including subtasks and spawned occurrences
+	At     time.Time `json:"at"`
+}
+
+// History lists the mutations that can be undone and redone, most recent first
+type History struct {
+	Undo []HistoryEntry `json:"undo"`
+	Redo []HistoryEntry `json:"redo"`
+}
+
+// TaskEvent is one entry of a task's audit log: the fields one mutation changed
+type TaskEvent struct {
+	ID      int64         `json:"id"`
+	TaskID  string        `json:"taskId"`
+	Action  Action        `json:"action"`
+	Actor   string        `json:"actor,omitempty"` // who made the change, when known
+	Via     string        `json:"via,omitempty"`   // the task the mutation was aimed at, when it was another one
+	Changes []FieldChange `json:"changes"`
+	At      time.Time     `json:"at"`
+}
+
+// FieldChange is the old and new value of one field; nil stands for unset
+// or, on creation and permanent deletion, for no task at all
+type FieldChange struct {
+	Field TaskField   `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
@@ -0,0 +1,64 @@
+package models
+
+// ConflictStrategy decides what happens to an imported task whose ID is
+// already taken
+type ConflictStrategy string
+
+const (
+	ConflictSkip      ConflictStrategy = "skip"      // keep the stored task; the default
+	ConflictOverwrite ConflictStrategy = "overwrite" // replace the stored task with the imported one
+	ConflictNewest    ConflictStrategy = "newest"    // overwrite only when the imported task was updated later
+	ConflictDuplicate ConflictStrategy = "duplicate" // import the task again under a new ID
+)
+
+// ImportOptions configures a bulk import
+type ImportOptions struct {
+	Strategy ConflictStrategy `json:"strategy,omitempty"`
+	DryRun   bool             `json:"dryRun,omitempty"` // report what would change without saving anything
+}
+
+// ImportOutcome is what an import does with one task
+type ImportOutcome string
+
+const (
+	ImportCreated ImportOutcome = "created"
+	ImportUpdated ImportOutcome = "updated"
+	ImportSkipped ImportOutcome = "skipped"
+)
+
+// Backup is what an export writes and an import reads: tasks together with
+// the projects and tags they refer to
+type Backup struct {
+	Projects []*Project `json:"projects,omitempty"`
+	Tags     []*Tag     `json:"tags,omitempty"`
+	Tasks    []*Task    `json:"tasks"`
+}
+
+// ImportResult reports what a bulk import did, or would do for a dry run
+type ImportResult struct {
+	Created  int           `json:"created"`
+	Updated  int           `json:"updated"`
+	Skipped  int           `json:"skipped"`            // tasks whose ID is taken and that were kept, or are unchanged
+	Projects int           `json:"projects,omitempty"` // projects created for the imported tasks
+	Tags     int           `json:"tags,omitempty"`     // tags created with the colors from the file
+	Dropped  []DroppedLink `json:"dropped,omitempty"`  // links of imported tasks that could not be kept
+	DryRun   bool          `json:"dryRun,omitempty"`
+	Items    []ImportItem  `json:"items,omitempty"` // every task of a dry run, in import order
+}
+
+// ImportItem is the outcome for one task of a dry run
+type ImportItem struct {
+	ID      string        `json:"id,omitempty"` // the stored ID; empty for a task that would get a new one
+	Title   string        `json:"title"`
+	Outcome ImportOutcome `json:"outcome"`
+	Changes []FieldChange `json:"changes,omitempty"` // fields an update replaces
+}
+
+// DroppedLink is a link of an imported task to a parent or project that is
+// neither in the file nor stored, or to a parent that would make a cycle
+type DroppedLink struct {
+	ID     string `json:"id,omitempty"` // the ID of the task in the file
+	Title  string `json:"title"`
+	Field  string `json:"field"`  // "parentId" or "projectId"
+	Target string `json:"target"` // the ID the link pointed to
+}
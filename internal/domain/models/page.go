@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TaskPage is one page of a task list
+type TaskPage struct {
+	Items      []*Task `json:"items"`
+	NextCursor string  `json:"nextCursor,omitempty"` // empty on the last page
+	Total      int     `json:"total"`                // tasks matching the filter on all pages
+}
+
+// PageCursor is the decoded FilterOptions.Cursor: the sort values of the
+// last task of a page, which the next page starts after
+type PageCursor struct {
+	Sort      string     `json:"s"` // ordering the cursor was made for
+	ID        string     `json:"id"`
+	Title     string     `json:"t,omitempty"`
+	Priority  Priority   `json:"p,omitempty"`
+	Status    Status     `json:"st,omitempty"`
+	DueDate   *time.Time `json:"d,omitempty"`
+	CreatedAt time.Time  `json:"c"`
+	UpdatedAt time.Time  `json:"u"`
+}
+
+// Task returns a task carrying the cursor's sort values, for comparing it
+// with the same code that orders tasks
+func (c *PageCursor) Task() *Task {
+	return &Task{
+		ID:        c.ID,
+		Title:     c.Title,
+		Priority:  c.Priority,
+		Status:    c.Status,
+		DueDate:   c.DueDate,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// NextCursor returns the opaque cursor of the page in the given ordering
+// that starts after task
+func NextCursor(keys []SortKey, task *Task) string {
+	cursor := PageCursor{
+		Sort:      sortSignature(keys),
+		ID:        task.ID,
+		Title:     task.Title,
+		Priority:  task.Priority,
+		Status:    task.Status,
+		DueDate:   task.DueDate,
+		CreatedAt: task.CreatedAt,
+		UpdatedAt: task.UpdatedAt,
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseCursor decodes a cursor for the given ordering, returning nil when it
+// is empty. A cursor made for a different ordering is rejected because it
+// would skip tasks.
+func ParseCursor(value string, keys []SortKey) (*PageCursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	var cursor PageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	if cursor.Sort != sortSignature(keys) {
+		return nil, fmt.Errorf("cursor was created for a different sort order")
+	}
+
+	return &cursor, nil
+}
+
+// sortSignature identifies an ordering, e.g. "priority:desc,due_date:asc"
+func sortSignature(keys []SortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.String()
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// Project represents a list that owns tasks
+type Project struct {
+	ID        string     `json:"id" db:"id"`
+	Name      string     `json:"name" db:"name"`
+	Color     string     `json:"color" db:"color"`
+	Archived  bool       `json:"archived" db:"archived"`
+	SortOrder int        `json:"sortOrder" db:"sort_order"`
+	Counts    TaskCounts `json:"counts"`
+	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+// TaskCounts holds the number of tasks in a project
+type TaskCounts struct {
+	Total     int `json:"total"`
+	Active    int `json:"active"`
+	Completed int `json:"completed"`
+}
+
+// CreateProjectRequest represents request to create a new project
+type CreateProjectRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// UpdateProjectRequest represents request to update a project
+type UpdateProjectRequest struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Color     string `json:"color"`
+	Archived  bool   `json:"archived"`
+	SortOrder int    `json:"sortOrder"`
+}
@@ -0,0 +1,266 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ part of a recurrence rule
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// untilLayout is the UTC DATE-TIME form used for UNTIL
+const untilLayout = "20060102T150405Z"
+
+// weekdayCodes maps RFC 5545 weekday codes to time.Weekday
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Recurrence is the supported subset of an RFC 5545 RRULE:
+// FREQ, INTERVAL, BYDAY (daily and weekly rules only), UNTIL and COUNT
+type Recurrence struct {
+	Freq      Frequency  `json:"freq"`
+	Interval  int        `json:"interval,omitempty"`  // defaults to 1 when omitted from JSON
+	ByWeekday []string   `json:"byWeekday,omitempty"` // "MO", "TU", ... "SU"
+	Until     *time.Time `json:"until,omitempty"`
+	Count     int        `json:"count,omitempty"` // total number of occurrences in the series
+}
+
+// ParseRecurrence parses an RRULE value such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR".
+// The "RRULE:" property prefix is accepted and ignored.
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return nil, fmt.Errorf("recurrence rule is empty")
+	}
+
+	r := &Recurrence{}
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+		case "INTERVAL":
+			// Only the JSON form may leave the interval at zero for the default
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q, expected a positive number", value)
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				r.ByWeekday = append(r.ByWeekday, strings.ToUpper(day))
+			}
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			r.Until = &until
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q, expected a positive number", value)
+			}
+			r.Count = n
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				return nil, fmt.Errorf("only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %q", key)
+		}
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// parseUntil accepts the DATE and UTC DATE-TIME forms of UNTIL
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse(untilLayout, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		// A DATE value includes the whole day
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q", value)
+}
+
+// Validate checks that the rule stays within the supported subset
+func (r *Recurrence) Validate() error {
+	switch r.Freq {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+	default:
+		return fmt.Errorf("unsupported recurrence frequency %q", r.Freq)
+	}
+
+	if r.Interval < 0 {
+		return fmt.Errorf("recurrence interval must be positive")
+	}
+	if r.Count < 0 {
+		return fmt.Errorf("recurrence count must be positive")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return fmt.Errorf("recurrence rule cannot have both UNTIL and COUNT")
+	}
+
+	if len(r.ByWeekday) > 0 && r.Freq != FrequencyDaily && r.Freq != FrequencyWeekly {
+		return fmt.Errorf("BYDAY is only supported for daily and weekly recurrence")
+	}
+	for _, day := range r.ByWeekday {
+		if _, ok := weekdayCodes[day]; !ok {
+			return fmt.Errorf("invalid weekday %q", day)
+		}
+	}
+
+	return nil
+}
+
+// String formats the rule as an RRULE value
+func (r *Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByWeekday) > 0 {
+		parts = append(parts, "BYDAY="+strings.Join(r.ByWeekday, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence strictly after prev, given that prev is
+// occurrence number `occurrence` (1-based) of the series. The second result is
+// false once the series has ended through COUNT or UNTIL, or when no later
+// day can match BYDAY.
+func (r *Recurrence) Next(prev time.Time, occurrence int) (time.Time, bool) {
+	if r.Count > 0 && occurrence >= r.Count {
+		return time.Time{}, false
+	}
+
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var next time.Time
+	switch r.Freq {
+	case FrequencyDaily:
+		var ok bool
+		if next, ok = r.nextDaily(prev, interval); !ok {
+			return time.Time{}, false
+		}
+	case FrequencyWeekly:
+		next = r.nextWeekly(prev, interval)
+	case FrequencyMonthly:
+		next = addSkippingInvalid(prev, 0, interval)
+	case FrequencyYearly:
+		next = addSkippingInvalid(prev, interval, 0)
+	default:
+		return time.Time{}, false
+	}
+
+	if r.Until != nil && next.After(*r.Until) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
+
+// nextDaily steps `interval` days at a time to the next BYDAY match. The
+// weekdays repeat after at most seven steps, so a rule whose steps never land
+// on a BYDAY weekday (e.g. INTERVAL=7 from another weekday) has no next occurrence.
+func (r *Recurrence) nextDaily(prev time.Time, interval int) (time.Time, bool) {
+	next := prev
+	for step := 0; step < 7; step++ {
+		next = next.AddDate(0, 0, interval)
+		if len(r.ByWeekday) == 0 || r.hasWeekday(next.Weekday()) {
+			return next, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// nextWeekly finds the next BYDAY match in the current week, or the first one
+// `interval` weeks later; weeks start on Monday
+func (r *Recurrence) nextWeekly(prev time.Time, interval int) time.Time {
+	if len(r.ByWeekday) == 0 {
+		return prev.AddDate(0, 0, 7*interval)
+	}
+
+	offset := (int(prev.Weekday()) + 6) % 7 // days since Monday
+	for d := offset + 1; d < 7; d++ {
+		candidate := prev.AddDate(0, 0, d-offset)
+		if r.hasWeekday(candidate.Weekday()) {
+			return candidate
+		}
+	}
+
+	weekStart := prev.AddDate(0, 0, 7*interval-offset)
+	for d := 0; d < 7; d++ {
+		candidate := weekStart.AddDate(0, 0, d)
+		if r.hasWeekday(candidate.Weekday()) {
+			return candidate
+		}
+	}
+
+	return weekStart
+}
+
+// hasWeekday reports whether the weekday is part of BYDAY
+func (r *Recurrence) hasWeekday(day time.Weekday) bool {
+	for _, code := range r.ByWeekday {
+		if weekdayCodes[code] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// addSkippingInvalid adds whole years or months and, like RFC 5545, skips
+// periods where the original day does not exist (e.g. the 31st or February 29th)
+func addSkippingInvalid(prev time.Time, years, months int) time.Time {
+	for step := 1; ; step++ {
+		year, month, day := prev.Date()
+		candidate := time.Date(year+years*step, month+time.Month(months*step), day,
+			prev.Hour(), prev.Minute(), prev.Second(), prev.Nanosecond(), prev.Location())
+		if candidate.Day() == day {
+			return candidate
+		}
+	}
+}
+
+// SetRecurrenceRequest represents request to set or clear (empty Rule) the recurrence of a task
+type SetRecurrenceRequest struct {
+	ID   string `json:"id"`
+	Rule string `json:"rule"` // RRULE value, e.g. "FREQ=WEEKLY;BYDAY=MO"
+}
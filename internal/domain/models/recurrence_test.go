@@ -0,0 +1,232 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextDailyByWeekday(t *testing.T) {
+	tuesday := time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		rule string
+		want time.Time // zero when the series has no next occurrence
+	}{
+		{"every day on Monday", "FREQ=DAILY;BYDAY=MO", time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)},
+		{"every other day on Friday", "FREQ=DAILY;INTERVAL=2;BYDAY=FR", time.Date(2026, 3, 13, 9, 0, 0, 0, time.UTC)},
+		{"weekly steps keep the weekday", "FREQ=DAILY;INTERVAL=7;BYDAY=TU", time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)},
+		{"weekly steps never reach Monday", "FREQ=DAILY;INTERVAL=7;BYDAY=MO", time.Time{}},
+		{"two-week steps never reach Monday", "FREQ=DAILY;INTERVAL=14;BYDAY=MO,WE", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q): %v", tt.rule, err)
+			}
+
+			done := make(chan struct{})
+			var got time.Time
+			var ok bool
+			go func() {
+				got, ok = r.Next(tuesday, 1)
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatalf("Next(%q) did not return", tt.rule)
+			}
+
+			if tt.want.IsZero() {
+				if ok {
+					t.Errorf("Next(%q) = %v, want no next occurrence", tt.rule, got)
+				}
+				return
+			}
+			if !ok || !got.Equal(tt.want) {
+				t.Errorf("Next(%q) = %v, %v, want %v", tt.rule, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRecurrenceErrors(t *testing.T) {
+	for _, rule := range []string{
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=-1",
+		"FREQ=DAILY;INTERVAL=x",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=3;UNTIL=20260310",
+		"FREQ=HOURLY",
+		"FREQ=MONTHLY;BYDAY=MO",
+	} {
+		if r, err := ParseRecurrence(rule); err == nil {
+			t.Errorf("ParseRecurrence(%q) = %+v, want an error", rule, r)
+		}
+	}
+}
+
+func TestZeroIntervalDefaultsToOne(t *testing.T) {
+	// The JSON form may omit the interval
+	r := &Recurrence{Freq: FrequencyDaily}
+	if err := r.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	prev := time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)
+	if got, ok := r.Next(prev, 1); !ok || !got.Equal(prev.AddDate(0, 0, 1)) {
+		t.Errorf("Next = %v, %v, want the next day", got, ok)
+	}
+}
+
+func TestNextSkipsMissingDays(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		from time.Time
+		want []time.Time
+	}{
+		{
+			"monthly on the 31st",
+			"FREQ=MONTHLY",
+			time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC),
+			[]time.Time{
+				time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 5, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 7, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 8, 31, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			"every other month on the 31st",
+			"FREQ=MONTHLY;INTERVAL=2",
+			time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC),
+			[]time.Time{
+				time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 5, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 7, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2027, 1, 31, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			"monthly on the 30th",
+			"FREQ=MONTHLY",
+			time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC),
+			[]time.Time{
+				time.Date(2026, 3, 30, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 4, 30, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			"yearly on February 29th",
+			"FREQ=YEARLY",
+			time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			[]time.Time{
+				time.Date(2028, 2, 29, 9, 0, 0, 0, time.UTC),
+				time.Date(2032, 2, 29, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			"every three years on February 29th",
+			"FREQ=YEARLY;INTERVAL=3",
+			time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			[]time.Time{
+				time.Date(2036, 2, 29, 9, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q): %v", tt.rule, err)
+			}
+
+			prev := tt.from
+			for i, want := range tt.want {
+				got, ok := r.Next(prev, i+1)
+				if !ok || !got.Equal(want) {
+					t.Fatalf("occurrence %d after %v = %v, %v, want %v", i+2, prev, got, ok, want)
+				}
+				prev = got
+			}
+		})
+	}
+}
+
+func TestNextEndsSeries(t *testing.T) {
+	tuesday := time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		rule string
+		want []time.Time // every occurrence after the first
+	}{
+		{
+			"count",
+			"FREQ=WEEKLY;COUNT=3",
+			[]time.Time{
+				time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 17, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{"count of one", "FREQ=DAILY;COUNT=1", nil},
+		{
+			"until a date-time includes it",
+			"FREQ=WEEKLY;UNTIL=20260317T090000Z",
+			[]time.Time{
+				time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 17, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			"until a date-time just before",
+			"FREQ=WEEKLY;UNTIL=20260317T085959Z",
+			[]time.Time{
+				time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			"until a date includes the whole day",
+			"FREQ=WEEKLY;UNTIL=20260317",
+			[]time.Time{
+				time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 3, 17, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			"until on a skipped day",
+			"FREQ=MONTHLY;UNTIL=20260430",
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q): %v", tt.rule, err)
+			}
+
+			from := tuesday
+			if r.Freq == FrequencyMonthly {
+				from = time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC)
+			}
+
+			prev := from
+			for i, want := range tt.want {
+				got, ok := r.Next(prev, i+1)
+				if !ok || !got.Equal(want) {
+					t.Fatalf("occurrence %d = %v, %v, want %v", i+2, got, ok, want)
+				}
+				prev = got
+			}
+			if got, ok := r.Next(prev, len(tt.want)+1); ok {
+				t.Errorf("occurrence %d = %v, want the series to have ended", len(tt.want)+2, got)
+			}
+		})
+	}
+}
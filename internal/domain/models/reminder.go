@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// MaxReminderOffset is the earliest a reminder may go off before the due date, in minutes
+const MaxReminderOffset = 30 * 24 * 60
+
+// Reminder goes off a number of minutes before its task is due, until it is
+// dismissed or the due date moves
+type Reminder struct {
+	ID           string     `json:"id"`
+	TaskID       string     `json:"taskId"`
+	Offset       int        `json:"offset"` // minutes before the due date
+	SnoozedUntil *time.Time `json:"snoozedUntil,omitempty"`
+	FiredFor     *time.Time `json:"firedFor,omitempty"` // the fire time last delivered or dismissed
+	DismissedAt  *time.Time `json:"dismissedAt,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+// FireAt returns when the reminder goes off for a due date: Offset minutes
+// before it, or at the end of a snooze reaching past that
+func (r *Reminder) FireAt(due time.Time) time.Time {
+	at := due.Add(-time.Duration(r.Offset) * time.Minute)
+	if r.SnoozedUntil != nil && r.SnoozedUntil.After(at) {
+		return *r.SnoozedUntil
+	}
+	return at
+}
+
+// Pending reports whether the reminder has yet to go off for a due date.
+// Moving the due date later or snoozing arms a fired reminder again.
+func (r *Reminder) Pending(due time.Time) bool {
+	return r.FiredFor == nil || r.FiredFor.Before(r.FireAt(due))
+}
+
+// SetRemindersRequest replaces the reminders of a task
+type SetRemindersRequest struct {
+	TaskID  string `json:"taskId"`
+	Offsets []int  `json:"offsets"` // minutes before the due date, e.g. [1440, 15]
+}
+
+// SnoozeReminderRequest postpones a reminder
+type SnoozeReminderRequest struct {
+	ID      string `json:"id"`
+	Minutes int    `json:"minutes"`
+}
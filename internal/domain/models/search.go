@@ -0,0 +1,118 @@
+package models
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// snippetWords is the number of words shown in a search snippet
+const snippetWords = 16
+
+// SearchResult is a task matching a full-text query
+type SearchResult struct {
+	Task    *Task   `json:"task"`
+	Rank    float64 `json:"rank"`    // higher is more relevant
+	Snippet string  `json:"snippet"` // HTML-escaped excerpt with matching words wrapped in <mark>
+}
+
+// Tokenize splits text into lowercase words of letters and digits. Every query
+// term matches the words it is a prefix of.
+func Tokenize(text string) []string {
+	var tokens []string
+	for _, span := range wordSpans(text) {
+		tokens = append(tokens, strings.ToLower(text[span[0]:span[1]]))
+	}
+	return tokens
+}
+
+// wordSpans returns the byte ranges of the words of text
+func wordSpans(text string) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+	return spans
+}
+
+// MatchesTerm reports whether a token matches a query term
+func MatchesTerm(token, term string) bool {
+	return strings.HasPrefix(token, term)
+}
+
+// Snippet returns an excerpt of the task around the first matching word,
+// taken from the description when it matches and from the title otherwise
+func Snippet(task *Task, terms []string) string {
+	if snippet, ok := highlight(task.Description, terms); ok {
+		return snippet
+	}
+	snippet, _ := highlight(task.Title, terms)
+	return snippet
+}
+
+// highlight escapes text, marks the words matching terms and trims it to a
+// window around the first match
+func highlight(text string, terms []string) (string, bool) {
+	spans := wordSpans(text)
+	matches := make([]bool, len(spans))
+	first := -1
+	for i, span := range spans {
+		token := strings.ToLower(text[span[0]:span[1]])
+		for _, term := range terms {
+			if MatchesTerm(token, term) {
+				matches[i] = true
+				break
+			}
+		}
+		if matches[i] && first < 0 {
+			first = i
+		}
+	}
+	if first < 0 {
+		return html.EscapeString(text), false
+	}
+
+	from := first - snippetWords/4
+	if from < 0 {
+		from = 0
+	}
+	to := from + snippetWords
+	if to > len(spans) {
+		to = len(spans)
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := spans[from][0]
+	for i := from; i < to; i++ {
+		span := spans[i]
+		b.WriteString(html.EscapeString(text[pos:span[0]]))
+		word := html.EscapeString(text[span[0]:span[1]])
+		if matches[i] {
+			b.WriteString("<mark>" + word + "</mark>")
+		} else {
+			b.WriteString(word)
+		}
+		pos = span[1]
+	}
+	if to < len(spans) {
+		b.WriteString("…")
+	} else {
+		b.WriteString(html.EscapeString(text[pos:]))
+	}
+
+	return b.String(), true
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortField is a task attribute that tasks can be ordered by
+type SortField string
+
+const (
+	SortCreatedAt SortField = "created_at"
+	SortUpdatedAt SortField = "updated_at"
+	SortDueDate   SortField = "due_date" // tasks without a due date come last in both directions
+	SortPriority  SortField = "priority"
+	SortStatus    SortField = "status"
+	SortTitle     SortField = "title"
+)
+
+// sortFields is the whitelist of sortable fields
+var sortFields = map[SortField]bool{
+	SortCreatedAt: true,
+	SortUpdatedAt: true,
+	SortDueDate:   true,
+	SortPriority:  true,
+	SortStatus:    true,
+	SortTitle:     true,
+}
+
+// SortDirection is the direction of one sort key
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// SortKey is one level of a task ordering, e.g. {"field": "priority", "direction": "desc"}
+type SortKey struct {
+	Field     SortField     `json:"field"`
+	Direction SortDirection `json:"direction,omitempty"` // defaults to asc
+}
+
+// Desc reports whether the key sorts in descending order
+func (k SortKey) Desc() bool {
+	return k.Direction == SortDesc
+}
+
+// String formats the key as "field:direction"
+func (k SortKey) String() string {
+	if k.Desc() {
+		return string(k.Field) + ":desc"
+	}
+	return string(k.Field) + ":asc"
+}
+
+// defaultSort lists the newest tasks first
+var defaultSort = []SortKey{{Field: SortCreatedAt, Direction: SortDesc}}
+
+// SortKeys returns the validated ordering requested by the filter: Sort when
+// set, otherwise the single key of SortBy and SortOrder, newest first by
+// default. Tasks with equal keys are always ordered by ID on top of these.
+func (f *FilterOptions) SortKeys() ([]SortKey, error) {
+	if f == nil {
+		return defaultSort, nil
+	}
+
+	keys := f.Sort
+	if len(keys) == 0 {
+		if f.SortBy == "" {
+			return defaultSort, nil
+		}
+		keys = []SortKey{{Field: SortField(f.SortBy), Direction: SortDirection(f.SortOrder)}}
+	}
+
+	seen := make(map[SortField]bool, len(keys))
+	result := make([]SortKey, len(keys))
+	for i, key := range keys {
+		if !sortFields[key.Field] {
+			return nil, fmt.Errorf("cannot sort by %q", key.Field)
+		}
+		if seen[key.Field] {
+			return nil, fmt.Errorf("%q is sorted by more than once", key.Field)
+		}
+		seen[key.Field] = true
+
+		switch strings.ToLower(string(key.Direction)) {
+		case "", string(SortAsc):
+			key.Direction = SortAsc
+		case string(SortDesc):
+			key.Direction = SortDesc
+		default:
+			return nil, fmt.Errorf("invalid sort direction %q", key.Direction)
+		}
+		result[i] = key
+	}
+
+	return result, nil
+}
+
+// ParseSort parses a sort specification such as "priority:desc,due_date".
+// Directions default to asc; the keys are validated by SortKeys.
+func ParseSort(spec string) []SortKey {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field, direction, _ := strings.Cut(part, ":")
+		keys = append(keys, SortKey{Field: SortField(strings.TrimSpace(field)), Direction: SortDirection(strings.TrimSpace(direction))})
+	}
+	return keys
+}
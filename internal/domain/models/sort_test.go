@@ -0,0 +1,67 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []SortKey
+	}{
+		{"", nil},
+		{"priority", []SortKey{{Field: SortPriority}}},
+		{"priority:desc,due_date", []SortKey{{Field: SortPriority, Direction: SortDesc}, {Field: SortDueDate}}},
+		{" title : asc , , created_at:DESC ", []SortKey{{Field: SortTitle, Direction: SortAsc}, {Field: SortCreatedAt, Direction: "DESC"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			if got := ParseSort(tt.spec); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSort(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  *FilterOptions
+		want    []SortKey
+		wantErr string
+	}{
+		{"nil filter", nil, defaultSort, ""},
+		{"no sort", &FilterOptions{}, defaultSort, ""},
+		{"sort by", &FilterOptions{SortBy: "title", SortOrder: "desc"}, []SortKey{{Field: SortTitle, Direction: SortDesc}}, ""},
+		{"sort by defaults to asc", &FilterOptions{SortBy: "priority"}, []SortKey{{Field: SortPriority, Direction: SortAsc}}, ""},
+		{"sort wins over sort by", &FilterOptions{Sort: ParseSort("due_date:desc"), SortBy: "title"}, []SortKey{{Field: SortDueDate, Direction: SortDesc}}, ""},
+		{"direction case", &FilterOptions{Sort: ParseSort("status:DESC,updated_at:Asc")}, []SortKey{{Field: SortStatus, Direction: SortDesc}, {Field: SortUpdatedAt, Direction: SortAsc}}, ""},
+		{"unknown field", &FilterOptions{Sort: ParseSort("priority,description")}, nil, `cannot sort by "description"`},
+		{"unknown sort by", &FilterOptions{SortBy: "id; DROP TABLE tasks"}, nil, "cannot sort by"},
+		{"column spelled as in Go", &FilterOptions{SortBy: "dueDate"}, nil, `cannot sort by "dueDate"`},
+		{"unknown direction", &FilterOptions{Sort: ParseSort("title:up")}, nil, `invalid sort direction "up"`},
+		{"unknown sort order", &FilterOptions{SortBy: "title", SortOrder: "descending"}, nil, "invalid sort direction"},
+		{"repeated field", &FilterOptions{Sort: ParseSort("title,title:desc")}, nil, `"title" is sorted by more than once`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.SortKeys()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("SortKeys() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SortKeys(): %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SortKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
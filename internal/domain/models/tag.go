@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// Tag represents a label that can be attached to any number of tasks
+type Tag struct {
+	ID        string    `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Color     string    `json:"color" db:"color"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+// CreateTagRequest represents request to create a new tag
+type CreateTagRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// UpdateTagRequest represents request to rename or recolor a tag
+type UpdateTagRequest struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
@@ -23,22 +23,34 @@ const (
 
 // Task represents a todo task
 type Task struct {
-	ID          string     `json:"id" db:"id"`
-	Title       string     `json:"title" db:"title"`
-	Description string     `json:"description" db:"description"`
-	Priority    Priority   `json:"priority" db:"priority"`
-	Status      Status     `json:"status" db:"status"`
-	DueDate     *time.Time `json:"dueDate,omitempty" db:"due_date"`
-	CreatedAt   time.Time  `json:"createdAt" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updatedAt" db:"updated_at"`
+	ID          string      `json:"id" db:"id"`
+	Title       string      `json:"title" db:"title"`
+	Description string      `json:"description" db:"description"`
+	Priority    Priority    `json:"priority" db:"priority"`
+	Status      Status      `json:"status" db:"status"`
+	DueDate     *time.Time  `json:"dueDate,omitempty" db:"due_date"`
+	Tags        []Tag       `json:"tags"`
+	ParentID    *string     `json:"parentId,omitempty" db:"parent_id"`
+	ProjectID   *string     `json:"projectId,omitempty" db:"project_id"`
+	Recurrence  *Recurrence `json:"recurrence,omitempty" db:"recurrence"`
+	SeriesID    *string     `json:"seriesId,omitempty" db:"series_id"`    // ID of the first task of a recurring series
+	Occurrence  int         `json:"occurrence,omitempty" db:"occurrence"` // 1-based position within the series
+	Version     int64       `json:"version" db:"version"`                 // incremented on every update
+	CreatedAt   time.Time   `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time   `json:"updatedAt" db:"updated_at"`
+	DeletedAt   *time.Time  `json:"deletedAt,omitempty" db:"deleted_at"` // set while the task is in the trash
 }
 
 // CreateTaskRequest represents request to create a new task
 type CreateTaskRequest struct {
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Priority    Priority   `json:"priority"`
-	DueDate     *time.Time `json:"dueDate,omitempty"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Priority    Priority    `json:"priority"`
+	DueDate     *time.Time  `json:"dueDate,omitempty"`
+	Tags        []string    `json:"tags,omitempty"` // tag names, created on demand
+	ParentID    *string     `json:"parentId,omitempty"`
+	ProjectID   *string     `json:"projectId,omitempty"` // inherited from the parent when omitted
+	Recurrence  *Recurrence `json:"recurrence,omitempty"`
 }
 
 // UpdateTaskRequest represents request to update a task
@@ -49,14 +61,69 @@ type UpdateTaskRequest struct {
 	Priority    Priority   `json:"priority"`
 	Status      Status     `json:"status"`
 	DueDate     *time.Time `json:"dueDate,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`      // nil keeps the current tags
+	ProjectID   *string    `json:"projectId,omitempty"` // nil keeps the current project, "" removes it
+	Version     int64      `json:"version"`             // version the client read; a stale version is a conflict
+}
+
+// PatchTaskRequest changes only the fields that are set; omitted fields keep their values
+type PatchTaskRequest struct {
+	ID           string     `json:"id"`
+	Version      int64      `json:"version"` // version the client read
+	Title        *string    `json:"title,omitempty"`
+	Description  *string    `json:"description,omitempty"`
+	Priority     *Priority  `json:"priority,omitempty"`
+	Status       *Status    `json:"status,omitempty"`
+	DueDate      *time.Time `json:"dueDate,omitempty"`
+	ClearDueDate bool       `json:"clearDueDate,omitempty"` // removes the due date; cannot be combined with DueDate
+	Tags         []string   `json:"tags,omitempty"`         // nil keeps the current tags, [] removes them
+	ProjectID    *string    `json:"projectId,omitempty"`    // "" removes the task from its project
 }
 
+// TaskField names a task attribute that a partial update can change
+type TaskField string
+
+const (
+	FieldTitle       TaskField = "title"
+	FieldDescription TaskField = "description"
+	FieldPriority    TaskField = "priority"
+	FieldStatus      TaskField = "status"
+	FieldDueDate     TaskField = "dueDate"
+	FieldTags        TaskField = "tags"
+	FieldProjectID   TaskField = "projectId"
+
+	// Fields changed by the service itself, never by a patch request
+	FieldParentID   TaskField = "parentId"
+	FieldRecurrence TaskField = "recurrence"
+	FieldDeletedAt  TaskField = "deletedAt"
+)
+
 // FilterOptions represents filtering and sorting options
 type FilterOptions struct {
-	Status    *Status    `json:"status,omitempty"`
-	Priority  *Priority  `json:"priority,omitempty"`
-	DateFrom  *time.Time `json:"dateFrom,omitempty"`
-	DateTo    *time.Time `json:"dateTo,omitempty"`
-	SortBy    string     `json:"sortBy"`    // "created_at", "due_date", "priority", "title"
-	SortOrder string     `json:"sortOrder"` // "asc", "desc"
+	Status      *Status    `json:"status,omitempty"`
+	Priority    *Priority  `json:"priority,omitempty"`
+	DueFrom     *time.Time `json:"dueFrom,omitempty"`     // due at or after; ranges include From and exclude To
+	DueTo       *time.Time `json:"dueTo,omitempty"`       // due before
+	CreatedFrom *time.Time `json:"createdFrom,omitempty"` // created at or after
+	CreatedTo   *time.Time `json:"createdTo,omitempty"`   // created before
+	UpdatedFrom *time.Time `json:"updatedFrom,omitempty"` // last updated at or after
+	UpdatedTo   *time.Time `json:"updatedTo,omitempty"`   // last updated before
+	DateFrom    *time.Time `json:"dateFrom,omitempty"`    // Deprecated: use CreatedFrom; created at or after
+	DateTo      *time.Time `json:"dateTo,omitempty"`      // Deprecated: use CreatedTo; created at or before, unlike CreatedTo
+	HasDueDate  *bool      `json:"hasDueDate,omitempty"`  // only tasks with (true) or without (false) a due date
+	Trashed     bool       `json:"trashed,omitempty"`     // only tasks in the trash instead of only live ones
+	Tags        []string   `json:"tags,omitempty"`        // task carries exactly these tags
+	AnyTags     []string   `json:"anyTags,omitempty"`     // task carries at least one of these tags
+	AllTags     []string   `json:"allTags,omitempty"`     // task carries every one of these tags
+	ParentID    *string    `json:"parentId,omitempty"`    // direct children of this task
+	RootOnly    bool       `json:"rootOnly,omitempty"`    // only tasks without a parent
+	SeriesID    *string    `json:"seriesId,omitempty"`    // occurrences of one recurring series
+	ProjectID   *string    `json:"projectId,omitempty"`   // tasks of this project, "" for tasks without one
+	Query       string     `json:"query,omitempty"`       // full-text search in title and description
+	Expression  string     `json:"expression,omitempty"`  // query language, e.g. `priority:high tag:backend "login bug"`
+	Sort        []SortKey  `json:"sort,omitempty"`        // ordering, e.g. priority desc then due_date asc
+	SortBy      string     `json:"sortBy"`                // single sort field when Sort is empty
+	SortOrder   string     `json:"sortOrder"`             // "asc", "desc"
+	Limit       int        `json:"limit,omitempty"`       // page size, 0 for all tasks
+	Cursor      string     `json:"cursor,omitempty"`      // nextCursor of the previous page
 }
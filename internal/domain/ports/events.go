@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// EventPublisher delivers domain events to their subscribers
+type EventPublisher interface {
+	Publish(ctx context.Context, event models.Event)
+}
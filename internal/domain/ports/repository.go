@@ -2,15 +2,91 @@ package ports
 
 import (
 	"context"
+	"time"
+
 	"todo-wails-go/internal/domain/models"
 )
 
 // TaskRepository defines the interface for task data operations
 type TaskRepository interface {
+	TagRepository
+	ProjectRepository
+	EventRepository
+	ReminderRepository
+	FeedRepository
+
 	Create(ctx context.Context, task *models.Task) error
+	// GetByID also returns a task in the trash; its DeletedAt is set
 	GetByID(ctx context.Context, id string) (*models.Task, error)
+	// GetAll returns the tasks matching filter in its sort order, starting after
+	// filter.Cursor and returning at most filter.Limit tasks when they are set.
+	// Trashed tasks are left out unless filter.Trashed selects only them.
 	GetAll(ctx context.Context, filter *models.FilterOptions) ([]*models.Task, error)
+	// Count returns the number of tasks matching filter, ignoring Cursor and Limit
+	Count(ctx context.Context, filter *models.FilterOptions) (int, error)
+	// Search retrieves the tasks matching filter.Query with a relevance rank, most relevant first
+	Search(ctx context.Context, filter *models.FilterOptions) ([]*models.SearchResult, error)
+	// GetDescendants retrieves every task below the given one, trashed or not
+	GetDescendants(ctx context.Context, id string) ([]*models.Task, error)
+	// Update saves the task only if its stored version still equals task.Version,
+	// then increments task.Version; otherwise it returns a domain conflict
 	Update(ctx context.Context, task *models.Task) error
+	// Patch is Update restricted to the given fields plus updated_at
+	Patch(ctx context.Context, task *models.Task, fields []models.TaskField) error
 	Delete(ctx context.Context, id string) error
+	// PurgeTrash permanently deletes the tasks trashed at or before the given
+	// time and returns how many were deleted
+	PurgeTrash(ctx context.Context, before time.Time) (int, error)
 	Close() error
 }
+
+// TagRepository defines the interface for tag data operations.
+// Task-to-tag links are persisted by TaskRepository.Create and Update from Task.Tags.
+type TagRepository interface {
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	GetTagByID(ctx context.Context, id string) (*models.Tag, error)
+	GetTagByName(ctx context.Context, name string) (*models.Tag, error)
+	GetTags(ctx context.Context) ([]*models.Tag, error)
+	UpdateTag(ctx context.Context, tag *models.Tag) error
+	DeleteTag(ctx context.Context, id string) error
+}
+
+// EventRepository stores the append-only audit log of task changes. Events
+// are kept after their task has been deleted for good.
+type EventRepository interface {
+	// AppendTaskEvents stores the events and sets their IDs
+	AppendTaskEvents(ctx context.Context, events []*models.TaskEvent) error
+	// GetTaskEvents retrieves the events of a task, oldest first
+	GetTaskEvents(ctx context.Context, taskID string) ([]*models.TaskEvent, error)
+}
+
+// ProjectRepository defines the interface for project data operations
+type ProjectRepository interface {
+	CreateProject(ctx context.Context, project *models.Project) error
+	GetProjectByID(ctx context.Context, id string) (*models.Project, error)
+	GetProjects(ctx context.Context, includeArchived bool) ([]*models.Project, error)
+	UpdateProject(ctx context.Context, project *models.Project) error
+	DeleteProject(ctx context.Context, id string) error
+	GetProjectTaskCounts(ctx context.Context) (map[string]models.TaskCounts, error) // keyed by project ID, "" for tasks without a project
+}
+
+// ReminderRepository stores task reminders; they are deleted with their task
+type ReminderRepository interface {
+	GetReminder(ctx context.Context, id string) (*models.Reminder, error)
+	// GetReminders retrieves the reminders of a task, earliest first
+	GetReminders(ctx context.Context, taskID string) ([]*models.Reminder, error)
+	// GetAllReminders retrieves the reminders of every task
+	GetAllReminders(ctx context.Context) ([]*models.Reminder, error)
+	// ReplaceReminders replaces the reminders of a task with the given ones
+	ReplaceReminders(ctx context.Context, taskID string, reminders []*models.Reminder) error
+	UpdateReminder(ctx context.Context, reminder *models.Reminder) error
+}
+
+// FeedRepository stores calendar feeds
+type FeedRepository interface {
+	CreateFeed(ctx context.Context, feed *models.Feed) error
+	GetFeedByToken(ctx context.Context, token string) (*models.Feed, error)
+	// GetFeeds retrieves every feed, oldest first
+	GetFeeds(ctx context.Context) ([]*models.Feed, error)
+	DeleteFeed(ctx context.Context, id string) error
+}
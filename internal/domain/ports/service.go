@@ -2,6 +2,8 @@ package ports
 
 import (
 	"context"
+	"time"
+
 	"todo-wails-go/internal/domain/models"
 )
 
@@ -10,7 +12,69 @@ type TaskService interface {
 	CreateTask(ctx context.Context, req *models.CreateTaskRequest) (*models.Task, error)
 	GetTask(ctx context.Context, id string) (*models.Task, error)
 	GetTasks(ctx context.Context, filter *models.FilterOptions) ([]*models.Task, error)
+	ListTasks(ctx context.Context, filter *models.FilterOptions) (*models.TaskPage, error)
 	UpdateTask(ctx context.Context, req *models.UpdateTaskRequest) (*models.Task, error)
+	PatchTask(ctx context.Context, req *models.PatchTaskRequest) (*models.Task, error)
+	SearchTasks(ctx context.Context, filter *models.FilterOptions) ([]*models.SearchResult, error)
+	// DeleteTask moves a task to the trash
 	DeleteTask(ctx context.Context, id string) error
 	ToggleTaskStatus(ctx context.Context, id string) (*models.Task, error)
+
+	GetChildren(ctx context.Context, id string) ([]*models.Task, error)
+	GetSubtree(ctx context.Context, id string) (*models.TaskNode, error)
+	MoveTask(ctx context.Context, req *models.MoveTaskRequest) (*models.Task, error)
+	SetRecurrence(ctx context.Context, req *models.SetRecurrenceRequest) (*models.Task, error)
+
+	ListTrash(ctx context.Context) ([]*models.Task, error)
+	RestoreTask(ctx context.Context, id string) (*models.Task, error)
+	EmptyTrash(ctx context.Context) (int, error)
+	PurgeExpiredTrash(ctx context.Context) (int, error)
+
+	// Undo and Redo step through the mutations of this service instance
+	Undo(ctx context.Context) (*models.HistoryEntry, error)
+	Redo(ctx context.Context) (*models.HistoryEntry, error)
+	History(ctx context.Context) (*models.History, error)
+
+	// GetTaskHistory returns the audit log of a task, oldest change first
+	GetTaskHistory(ctx context.Context, id string) ([]*models.TaskEvent, error)
+
+	// ExportTasks returns the tasks matching the filter, live and trashed,
+	// with every project and tag
+	ExportTasks(ctx context.Context, filter *models.FilterOptions) (*models.Backup, error)
+
+	// ImportTasks creates tasks read from a file as one undoable command; nil
+	// options skip tasks whose ID is taken
+	ImportTasks(ctx context.Context, data *models.Backup, opts *models.ImportOptions) (*models.ImportResult, error)
+
+	GetReminders(ctx context.Context, taskID string) ([]*models.Reminder, error)
+	SetReminders(ctx context.Context, req *models.SetRemindersRequest) ([]*models.Reminder, error)
+	SnoozeReminder(ctx context.Context, req *models.SnoozeReminderRequest) (*models.Reminder, error)
+	DismissReminder(ctx context.Context, id string) (*models.Reminder, error)
+	// FireDueReminders delivers the reminders due at now and returns when the next one is
+	FireDueReminders(ctx context.Context, now time.Time) (time.Time, error)
+
+	CreateTag(ctx context.Context, req *models.CreateTagRequest) (*models.Tag, error)
+	GetTags(ctx context.Context) ([]*models.Tag, error)
+	UpdateTag(ctx context.Context, req *models.UpdateTagRequest) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id string) error
+	AddTagToTask(ctx context.Context, taskID, tagName string) (*models.Task, error)
+	RemoveTagFromTask(ctx context.Context, taskID, tagID string) (*models.Task, error)
+}
+
+// FeedService defines the interface for calendar feed business logic
+type FeedService interface {
+	CreateFeed(ctx context.Context, req *models.CreateFeedRequest) (*models.Feed, error)
+	GetFeeds(ctx context.Context) ([]*models.Feed, error) // without tokens
+	DeleteFeed(ctx context.Context, id string) error
+	// GetFeedTasks retrieves the feed with the given token and its tasks
+	GetFeedTasks(ctx context.Context, token string) (*models.Feed, []*models.Task, error)
+}
+
+// ProjectService defines the interface for project business logic
+type ProjectService interface {
+	CreateProject(ctx context.Context, req *models.CreateProjectRequest) (*models.Project, error)
+	GetProject(ctx context.Context, id string) (*models.Project, error)
+	GetProjects(ctx context.Context, includeArchived bool) ([]*models.Project, error)
+	UpdateProject(ctx context.Context, req *models.UpdateProjectRequest) (*models.Project, error)
+	DeleteProject(ctx context.Context, id string) error
 }
@@ -0,0 +1,108 @@
+// Package query implements the task query language, for example
+//
+//	priority:high status:active due:<2026-11-01 tag:backend "login bug"
+//
+// Terms are combined with AND unless separated by OR; "-" or NOT negates a
+// term and parentheses group them. Parse produces an AST that the
+// repositories compile to SQL or evaluate with Match.
+package query
+
+import (
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// Expr is a node of the query AST
+type Expr interface {
+	// Pos returns the 1-based position of the node in the query text
+	Pos() int
+}
+
+// And matches tasks that match every expression
+type And struct {
+	Exprs []Expr
+	At    int
+}
+
+// Or matches tasks that match at least one expression
+type Or struct {
+	Exprs []Expr
+	At    int
+}
+
+// Not matches tasks that do not match the expression
+type Not struct {
+	Expr Expr
+	At   int
+}
+
+// Field is a task attribute that a comparison can test
+type Field string
+
+const (
+	FieldPriority Field = "priority"
+	FieldStatus   Field = "status"
+	FieldDue      Field = "due"
+	FieldCreated  Field = "created"
+	FieldUpdated  Field = "updated"
+	FieldTag      Field = "tag"
+	FieldProject  Field = "project"
+)
+
+// Op is a comparison operator
+type Op string
+
+const (
+	OpEq Op = "="
+	OpLt Op = "<"
+	OpLe Op = "<="
+	OpGt Op = ">"
+	OpGe Op = ">="
+)
+
+// Compare tests one field, e.g. priority:>=medium or due:<2026-11-01.
+// Only the value matching the field is set.
+type Compare struct {
+	Field    Field
+	Op       Op
+	Priority models.Priority // priority
+	Status   models.Status   // status
+	Day      time.Time       // due, created, updated: midnight of the day in local time
+	Name     string          // tag name (lowercase) or project ID
+	None     bool            // due:none, tag:none, project:none
+	At       int
+}
+
+// Text is a full-text term matching title and description words by prefix.
+// A phrase requires its words to be adjacent and in order.
+type Text struct {
+	Words  []string
+	Phrase bool
+	At     int
+}
+
+func (e *And) Pos() int     { return e.At }
+func (e *Or) Pos() int      { return e.At }
+func (e *Not) Pos() int     { return e.At }
+func (e *Compare) Pos() int { return e.At }
+func (e *Text) Pos() int    { return e.At }
+
+// DayRange returns the bounds [from, to) of a day-granular comparison;
+// a nil bound is unbounded
+func (e *Compare) DayRange() (from, to *time.Time) {
+	start := e.Day
+	next := e.Day.AddDate(0, 0, 1)
+	switch e.Op {
+	case OpLt:
+		return nil, &start
+	case OpLe:
+		return nil, &next
+	case OpGt:
+		return &next, nil
+	case OpGe:
+		return &start, nil
+	default:
+		return &start, &next
+	}
+}
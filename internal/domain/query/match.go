@@ -0,0 +1,123 @@
+package query
+
+import (
+	"strings"
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// Match evaluates an expression against a task. It is the reference
+// semantics that the SQL compilation of the repositories reproduces;
+// a nil expression matches every task.
+func Match(expr Expr, task *models.Task) bool {
+	switch e := expr.(type) {
+	case nil:
+		return true
+	case *And:
+		for _, sub := range e.Exprs {
+			if !Match(sub, task) {
+				return false
+			}
+		}
+		return true
+	case *Or:
+		for _, sub := range e.Exprs {
+			if Match(sub, task) {
+				return true
+			}
+		}
+		return false
+	case *Not:
+		return !Match(e.Expr, task)
+	case *Compare:
+		return matchCompare(e, task)
+	case *Text:
+		return matchText(e.Words, models.Tokenize(task.Title)) ||
+			matchText(e.Words, models.Tokenize(task.Description))
+	default:
+		return false
+	}
+}
+
+func matchCompare(e *Compare, task *models.Task) bool {
+	switch e.Field {
+	case FieldPriority:
+		return compareInts(int(task.Priority), int(e.Priority), e.Op)
+	case FieldStatus:
+		return task.Status == e.Status
+	case FieldDue:
+		if e.None {
+			return task.DueDate == nil
+		}
+		// Tasks without a due date match no date comparison
+		return task.DueDate != nil && inDayRange(e, *task.DueDate)
+	case FieldCreated:
+		return inDayRange(e, task.CreatedAt)
+	case FieldUpdated:
+		return inDayRange(e, task.UpdatedAt)
+	case FieldTag:
+		if e.None {
+			return len(task.Tags) == 0
+		}
+		for _, tag := range task.Tags {
+			if strings.ToLower(tag.Name) == e.Name {
+				return true
+			}
+		}
+		return false
+	case FieldProject:
+		if e.None {
+			return task.ProjectID == nil
+		}
+		return task.ProjectID != nil && *task.ProjectID == e.Name
+	default:
+		return false
+	}
+}
+
+// compareInts applies an operator to two ordered values
+func compareInts(a, b int, op Op) bool {
+	switch op {
+	case OpLt:
+		return a < b
+	case OpLe:
+		return a <= b
+	case OpGt:
+		return a > b
+	case OpGe:
+		return a >= b
+	default:
+		return a == b
+	}
+}
+
+// inDayRange reports whether t falls into the day range of a comparison
+func inDayRange(e *Compare, t time.Time) bool {
+	from, to := e.DayRange()
+	if from != nil && t.Before(*from) {
+		return false
+	}
+	if to != nil && !t.Before(*to) {
+		return false
+	}
+	return true
+}
+
+// matchText reports whether the tokens contain the words in order, each
+// token matching its word by prefix
+func matchText(words, tokens []string) bool {
+	for start := 0; start+len(words) <= len(tokens); start++ {
+		matched := true
+		for i, word := range words {
+			if !models.MatchesTerm(tokens[start+i], word) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
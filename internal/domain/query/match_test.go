@@ -0,0 +1,77 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+func TestMatch(t *testing.T) {
+	due := time.Date(2026, 10, 20, 15, 0, 0, 0, time.Local)
+	project := "p1"
+	task := &models.Task{
+		Title:       "Fix login bug",
+		Description: "Users on Safari cannot sign in",
+		Priority:    models.PriorityHigh,
+		Status:      models.StatusActive,
+		DueDate:     &due,
+		ProjectID:   &project,
+		Tags:        []models.Tag{{Name: "Backend"}},
+		CreatedAt:   time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local),
+		UpdatedAt:   time.Date(2026, 10, 2, 9, 0, 0, 0, time.Local),
+	}
+	undated := &models.Task{Title: "Write notes", Status: models.StatusCompleted}
+
+	tests := []struct {
+		query string
+		task  *models.Task
+		want  bool
+	}{
+		{"", task, true},
+		{"priority:high", task, true},
+		{"priority:>=medium", task, true},
+		{"priority:<medium", task, false},
+		{"status:active", task, true},
+		{"status:done", task, false},
+		{"due:2026-10-20", task, true},
+		{"due:<2026-10-20", task, false},
+		{"due:<=2026-10-20", task, true},
+		{"due:>2026-10-19", task, true},
+		{"due:>2026-10-20", task, false},
+		{"due:none", task, false},
+		{"due:none", undated, true},
+		{"due:<2026-12-01", undated, false},
+		{"-due:<2026-12-01", undated, true},
+		{"created:2026-10-01", task, true},
+		{"updated:>=2026-10-03", task, false},
+		{"tag:backend", task, true},
+		{"tag:none", task, false},
+		{"tag:none", undated, true},
+		{"-tag:frontend", task, true},
+		{"project:p1", task, true},
+		{"project:p2", task, false},
+		{"project:none", undated, true},
+		{"log", task, true},
+		{"safari", task, true},
+		{`"login bug"`, task, true},
+		{`"bug login"`, task, false},
+		{"tag:frontend OR priority:high", task, true},
+		{"tag:frontend OR priority:low", task, false},
+		{"(tag:frontend OR tag:backend) status:active", task, true},
+		{"NOT status:active", task, false},
+		{"status:active AND -login", task, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.query, err)
+			}
+			if got := Match(expr, tt.task); got != tt.want {
+				t.Errorf("Match(%q, %q) = %v, want %v", tt.query, tt.task.Title, got, tt.want)
+			}
+		})
+	}
+}
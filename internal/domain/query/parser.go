@@ -0,0 +1,396 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"todo-wails-go/internal/domain"
+	"todo-wails-go/internal/domain/models"
+)
+
+// field is the request field that query syntax errors refer to
+const field = "expression"
+
+// dateLayout is the format of date values
+const dateLayout = "2006-01-02"
+
+// tokenKind classifies the tokens of a query
+type tokenKind int
+
+const (
+	tokenEOF    tokenKind = iota
+	tokenWord             // bare word or field:value, quotes inside it kept
+	tokenPhrase           // "quoted words"
+	tokenLParen
+	tokenRParen
+	tokenMinus // "-" directly before a term
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+// token is a lexeme with its 1-based rune position
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// syntaxError reports a problem at a position of the query
+func syntaxError(pos int, format string, args ...interface{}) error {
+	message := fmt.Sprintf(format, args...)
+	return &domain.ValidationError{
+		Field:    field,
+		Message:  fmt.Sprintf("%s at position %d", message, pos),
+		Position: pos,
+	}
+}
+
+// lex splits a query into tokens
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			i++
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && runes[i+1] != ')':
+			tokens = append(tokens, token{kind: tokenMinus, text: "-", pos: pos})
+			i++
+		case r == '"':
+			end := closingQuote(runes, i)
+			if end < 0 {
+				return nil, syntaxError(pos, "unterminated quote")
+			}
+			tokens = append(tokens, token{kind: tokenPhrase, text: string(runes[i+1 : end]), pos: pos})
+			i = end + 1
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				if runes[i] == '"' {
+					end := closingQuote(runes, i)
+					if end < 0 {
+						return nil, syntaxError(i+1, "unterminated quote")
+					}
+					i = end
+				}
+				i++
+			}
+			text := string(runes[start:i])
+			kind := tokenWord
+			switch text {
+			case "AND":
+				kind = tokenAnd
+			case "OR":
+				kind = tokenOr
+			case "NOT":
+				kind = tokenNot
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: pos})
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+// closingQuote returns the index of the quote closing the one at open, or -1
+func closingQuote(runes []rune, open int) int {
+	for i := open + 1; i < len(runes); i++ {
+		if runes[i] == '"' {
+			return i
+		}
+	}
+	return -1
+}
+
+// parser is a recursive descent parser over the tokens of a query:
+//
+//	or      = and { "OR" and }
+//	and     = unary { ["AND"] unary }
+//	unary   = ("-" | "NOT") unary | primary
+//	primary = "(" or ")" | term
+type parser struct {
+	tokens []token
+	next   int
+}
+
+// Parse parses a query into its AST. An empty query returns a nil Expr.
+// Syntax errors are *domain.ValidationError values carrying the position.
+func Parse(input string) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, nil
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, syntaxError(tok.pos, "unexpected %q", tok.text)
+	}
+	return expr, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	pos := p.peek().pos
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []Expr{first}
+	for p.peek().kind == tokenOr {
+		p.advance()
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+
+	if len(exprs) == 1 {
+		return first, nil
+	}
+	return &Or{Exprs: exprs, At: pos}, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	pos := p.peek().pos
+	var exprs []Expr
+	for {
+		switch p.peek().kind {
+		case tokenEOF, tokenRParen, tokenOr:
+			if len(exprs) == 0 {
+				tok := p.peek()
+				if tok.kind == tokenEOF {
+					return nil, syntaxError(tok.pos, "missing term")
+				}
+				return nil, syntaxError(tok.pos, "unexpected %q", tok.text)
+			}
+			if len(exprs) == 1 {
+				return exprs[0], nil
+			}
+			return &And{Exprs: exprs, At: pos}, nil
+		case tokenAnd:
+			if len(exprs) == 0 {
+				tok := p.peek()
+				return nil, syntaxError(tok.pos, "unexpected %q", tok.text)
+			}
+			p.advance()
+			if kind := p.peek().kind; kind == tokenEOF || kind == tokenRParen || kind == tokenOr || kind == tokenAnd {
+				return nil, syntaxError(p.peek().pos, "missing term after AND")
+			}
+		}
+
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	tok := p.peek()
+	if tok.kind != tokenMinus && tok.kind != tokenNot {
+		return p.parsePrimary()
+	}
+
+	p.advance()
+	if kind := p.peek().kind; kind == tokenEOF || kind == tokenRParen || kind == tokenOr || kind == tokenAnd {
+		return nil, syntaxError(p.peek().pos, "missing term after %s", tok.text)
+	}
+	expr, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &Not{Expr: expr, At: tok.pos}, nil
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokenLParen:
+		if p.peek().kind == tokenRParen {
+			return nil, syntaxError(tok.pos, "empty parentheses")
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, syntaxError(tok.pos, "unclosed parenthesis")
+		}
+		p.advance()
+		return expr, nil
+	case tokenPhrase:
+		return textTerm(tok.text, tok.pos, true)
+	case tokenWord:
+		return p.parseTerm(tok)
+	case tokenEOF:
+		return nil, syntaxError(tok.pos, "missing term")
+	default:
+		return nil, syntaxError(tok.pos, "unexpected %q", tok.text)
+	}
+}
+
+// textTerm builds a full-text term from a word or a quoted phrase
+func textTerm(text string, pos int, quoted bool) (Expr, error) {
+	words := models.Tokenize(text)
+	if len(words) == 0 {
+		return nil, syntaxError(pos, "%q contains no searchable words", text)
+	}
+	// A bare word such as "e-mail" splits into adjacent words, like a phrase
+	return &Text{Words: words, Phrase: quoted || len(words) > 1, At: pos}, nil
+}
+
+// parseTerm parses a bare word, which is either field:value or text
+func (p *parser) parseTerm(tok token) (Expr, error) {
+	name, value, ok := strings.Cut(tok.text, ":")
+	if !ok || strings.ContainsRune(name, '"') {
+		return textTerm(unquote(tok.text), tok.pos, strings.Contains(tok.text, `"`))
+	}
+
+	cmp := &Compare{Field: Field(strings.ToLower(name)), Op: OpEq, At: tok.pos}
+	valuePos := tok.pos + len([]rune(name)) + 1
+
+	for _, op := range []Op{OpLe, OpGe, OpLt, OpGt, OpEq} {
+		if strings.HasPrefix(value, string(op)) {
+			cmp.Op = op
+			value = value[len(op):]
+			valuePos += len(op)
+			break
+		}
+	}
+
+	value = unquote(value)
+	if value == "" {
+		return nil, syntaxError(valuePos, "missing value for %s", name)
+	}
+
+	var err error
+	switch cmp.Field {
+	case FieldPriority:
+		err = parsePriority(cmp, value, valuePos)
+	case FieldStatus:
+		err = parseStatus(cmp, value, valuePos)
+	case FieldDue, FieldCreated, FieldUpdated:
+		err = parseDate(cmp, value, valuePos)
+	case FieldTag, FieldProject:
+		err = parseName(cmp, value, valuePos)
+	default:
+		return nil, syntaxError(tok.pos, "unknown field %q", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cmp, nil
+}
+
+// unquote removes the quotes of a quoted value such as "code review"
+func unquote(value string) string {
+	return strings.ReplaceAll(value, `"`, "")
+}
+
+// requireEq rejects ordering operators on fields that have no order
+func requireEq(cmp *Compare, pos int) error {
+	if cmp.Op != OpEq {
+		return syntaxError(pos-len(cmp.Op), "operator %s is not supported for %s", cmp.Op, cmp.Field)
+	}
+	return nil
+}
+
+func parsePriority(cmp *Compare, value string, pos int) error {
+	switch strings.ToLower(value) {
+	case "low", "0":
+		cmp.Priority = models.PriorityLow
+	case "medium", "1":
+		cmp.Priority = models.PriorityMedium
+	case "high", "2":
+		cmp.Priority = models.PriorityHigh
+	default:
+		return syntaxError(pos, "invalid priority %q, expected low, medium or high", value)
+	}
+	return nil
+}
+
+func parseStatus(cmp *Compare, value string, pos int) error {
+	if err := requireEq(cmp, pos); err != nil {
+		return err
+	}
+	switch strings.ToLower(value) {
+	case "active", "open":
+		cmp.Status = models.StatusActive
+	case "completed", "done":
+		cmp.Status = models.StatusCompleted
+	default:
+		return syntaxError(pos, "invalid status %q, expected active or completed", value)
+	}
+	return nil
+}
+
+func parseDate(cmp *Compare, value string, pos int) error {
+	if cmp.Field == FieldDue && strings.EqualFold(value, "none") {
+		cmp.None = true
+		return requireEq(cmp, pos)
+	}
+
+	day, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		return syntaxError(pos, "invalid date %q, expected YYYY-MM-DD", value)
+	}
+	cmp.Day = day
+	return nil
+}
+
+func parseName(cmp *Compare, value string, pos int) error {
+	if err := requireEq(cmp, pos); err != nil {
+		return err
+	}
+	if strings.EqualFold(value, "none") {
+		cmp.None = true
+		return nil
+	}
+	if cmp.Field == FieldTag {
+		value = strings.ToLower(strings.TrimSpace(value))
+	}
+	cmp.Name = value
+	return nil
+}
+
+// Validate reports the first syntax error of a query
+func Validate(input string) error {
+	_, err := Parse(input)
+	return err
+}
@@ -0,0 +1,120 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"todo-wails-go/internal/domain"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		check func(t *testing.T, expr Expr)
+	}{
+		{"empty query", "  ", func(t *testing.T, expr Expr) {
+			if expr != nil {
+				t.Errorf("got %#v, want nil", expr)
+			}
+		}},
+		{"implicit AND", "tag:backend priority:high", func(t *testing.T, expr Expr) {
+			and, ok := expr.(*And)
+			if !ok || len(and.Exprs) != 2 {
+				t.Fatalf("got %#v, want And of two terms", expr)
+			}
+		}},
+		{"OR binds looser than AND", "a b OR c", func(t *testing.T, expr Expr) {
+			or, ok := expr.(*Or)
+			if !ok || len(or.Exprs) != 2 {
+				t.Fatalf("got %#v, want Or of two terms", expr)
+			}
+			if _, ok := or.Exprs[0].(*And); !ok {
+				t.Errorf("first operand is %#v, want And", or.Exprs[0])
+			}
+		}},
+		{"operator and position", "due:<=2026-11-01", func(t *testing.T, expr Expr) {
+			cmp, ok := expr.(*Compare)
+			if !ok || cmp.Field != FieldDue || cmp.Op != OpLe || cmp.At != 1 {
+				t.Fatalf("got %#v, want due <= at 1", expr)
+			}
+			if got := cmp.Day.Format(dateLayout); got != "2026-11-01" {
+				t.Errorf("day = %s, want 2026-11-01", got)
+			}
+		}},
+		{"negation", "-tag:frontend", func(t *testing.T, expr Expr) {
+			not, ok := expr.(*Not)
+			if !ok {
+				t.Fatalf("got %#v, want Not", expr)
+			}
+			if cmp, ok := not.Expr.(*Compare); !ok || cmp.Name != "frontend" || cmp.At != 2 {
+				t.Errorf("negated %#v, want tag frontend at 2", not.Expr)
+			}
+		}},
+		{"quoted phrase", `"login bug"`, func(t *testing.T, expr Expr) {
+			text, ok := expr.(*Text)
+			if !ok || !text.Phrase || strings.Join(text.Words, " ") != "login bug" {
+				t.Fatalf("got %#v, want phrase login bug", expr)
+			}
+		}},
+		{"quoted value", `tag:"Code Review"`, func(t *testing.T, expr Expr) {
+			cmp, ok := expr.(*Compare)
+			if !ok || cmp.Name != "code review" {
+				t.Fatalf("got %#v, want tag code review", expr)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.query, err)
+			}
+			tt.check(t, expr)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		position int
+		message  string
+	}{
+		{"unterminated phrase", `title "login bug`, 7, "unterminated quote"},
+		{"unterminated value", `tag:"code review`, 5, "unterminated quote"},
+		{"dangling OR", "tag:a OR", 9, "missing term"},
+		{"leading OR", "OR tag:a", 1, `unexpected "OR"`},
+		{"double OR", "a OR OR b", 6, `unexpected "OR"`},
+		{"dangling AND", "a AND", 6, "missing term after AND"},
+		{"dangling NOT", "NOT", 4, "missing term after NOT"},
+		{"bad due date", "due:2026-13-01", 5, "invalid date"},
+		{"bad due date after operator", "status:active due:<2026-02-30", 20, "invalid date"},
+		{"bad priority", "priority:urgent", 10, "invalid priority"},
+		{"ordered status", "status:<done", 8, "operator < is not supported"},
+		{"missing value", "tag:", 5, "missing value"},
+		{"unknown field", "a color:red", 3, `unknown field "color"`},
+		{"unclosed parenthesis", "(a OR b", 1, "unclosed parenthesis"},
+		{"empty parentheses", "a ()", 3, "empty parentheses"},
+		{"stray parenthesis", "a )", 3, `unexpected ")"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			var verr *domain.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Parse(%q) error = %v, want a validation error", tt.query, err)
+			}
+			if verr.Field != field || verr.Position != tt.position {
+				t.Errorf("Parse(%q) error at %s:%d, want %s:%d", tt.query, verr.Field, verr.Position, field, tt.position)
+			}
+			if !strings.Contains(verr.Message, tt.message) {
+				t.Errorf("Parse(%q) error = %q, want it to contain %q", tt.query, verr.Message, tt.message)
+			}
+		})
+	}
+}
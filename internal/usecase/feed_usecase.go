@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
+)
+
+// FeedUseCase implements the calendar feed use cases
+type FeedUseCase struct {
+	service ports.FeedService
+}
+
+// NewFeedUseCase creates a new feed use case
+func NewFeedUseCase(service ports.FeedService) *FeedUseCase {
+	return &FeedUseCase{service: service}
+}
+
+// CreateFeed creates a new feed
+func (uc *FeedUseCase) CreateFeed(ctx context.Context, req *models.CreateFeedRequest) (*models.Feed, error) {
+	return uc.service.CreateFeed(ctx, req)
+}
+
+// GetFeeds retrieves all feeds
+func (uc *FeedUseCase) GetFeeds(ctx context.Context) ([]*models.Feed, error) {
+	return uc.service.GetFeeds(ctx)
+}
+
+// DeleteFeed deletes a feed by ID
+func (uc *FeedUseCase) DeleteFeed(ctx context.Context, id string) error {
+	return uc.service.DeleteFeed(ctx, id)
+}
+
+// GetFeedTasks retrieves a feed by its token with its tasks
+func (uc *FeedUseCase) GetFeedTasks(ctx context.Context, token string) (*models.Feed, []*models.Task, error) {
+	return uc.service.GetFeedTasks(ctx, token)
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+
+	"todo-wails-go/internal/domain/models"
+	"todo-wails-go/internal/domain/ports"
+)
+
+// ProjectUseCase implements the project use cases
+type ProjectUseCase struct {
+	service ports.ProjectService
+}
+
+// NewProjectUseCase creates a new project use case
+func NewProjectUseCase(service ports.ProjectService) *ProjectUseCase {
+	return &ProjectUseCase{service: service}
+}
+
+// CreateProject creates a new project
+func (uc *ProjectUseCase) CreateProject(ctx context.Context, req *models.CreateProjectRequest) (*models.Project, error) {
+	return uc.service.CreateProject(ctx, req)
+}
+
+// GetProject retrieves a project by ID
+func (uc *ProjectUseCase) GetProject(ctx context.Context, id string) (*models.Project, error) {
+	return uc.service.GetProject(ctx, id)
+}
+
+// GetProjects retrieves all projects with their task counts
+func (uc *ProjectUseCase) GetProjects(ctx context.Context, includeArchived bool) ([]*models.Project, error) {
+	return uc.service.GetProjects(ctx, includeArchived)
+}
+
+// UpdateProject updates an existing project
+func (uc *ProjectUseCase) UpdateProject(ctx context.Context, req *models.UpdateProjectRequest) (*models.Project, error) {
+	return uc.service.UpdateProject(ctx, req)
+}
+
+// DeleteProject deletes a project by ID
+func (uc *ProjectUseCase) DeleteProject(ctx context.Context, id string) error {
+	return uc.service.DeleteProject(ctx, id)
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+
+	"todo-wails-go/internal/domain/models"
+)
+
+// CreateTag creates a new tag
+func (uc *TaskUseCase) CreateTag(ctx context.Context, req *models.CreateTagRequest) (*models.Tag, error) {
+	return uc.service.CreateTag(ctx, req)
+}
+
+// GetTags retrieves all tags
+func (uc *TaskUseCase) GetTags(ctx context.Context) ([]*models.Tag, error) {
+	return uc.service.GetTags(ctx)
+}
+
+// UpdateTag renames or recolors a tag
+func (uc *TaskUseCase) UpdateTag(ctx context.Context, req *models.UpdateTagRequest) (*models.Tag, error) {
+	return uc.service.UpdateTag(ctx, req)
+}
+
+// DeleteTag deletes a tag
+func (uc *TaskUseCase) DeleteTag(ctx context.Context, id string) error {
+	return uc.service.DeleteTag(ctx, id)
+}
+
+// AddTagToTask attaches a tag to a task by name
+func (uc *TaskUseCase) AddTagToTask(ctx context.Context, taskID, tagName string) (*models.Task, error) {
+	return uc.service.AddTagToTask(ctx, taskID, tagName)
+}
+
+// RemoveTagFromTask detaches a tag from a task
+func (uc *TaskUseCase) RemoveTagFromTask(ctx context.Context, taskID, tagID string) (*models.Task, error) {
+	return uc.service.RemoveTagFromTask(ctx, taskID, tagID)
+}
+
+// GetTasksByTags retrieves tasks carrying at least one of the given tags
+func (uc *TaskUseCase) GetTasksByTags(ctx context.Context, tags []string) ([]*models.Task, error) {
+	filter := &models.FilterOptions{
+		AnyTags: tags,
+		Sort:    []models.SortKey{{Field: models.SortCreatedAt, Direction: models.SortDesc}},
+	}
+	return uc.service.GetTasks(ctx, filter)
+}
@@ -2,20 +2,25 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"time"
 
+	"todo-wails-go/internal/adapter/todotxt"
+	"todo-wails-go/internal/domain"
 	"todo-wails-go/internal/domain/models"
 	"todo-wails-go/internal/domain/ports"
 )
 
 // TaskUseCase implements the application use cases
 type TaskUseCase struct {
-	service ports.TaskService
+	service  ports.TaskService
+	projects ports.ProjectService // names the projects of todo.txt files
 }
 
 // NewTaskUseCase creates a new task use case
-func NewTaskUseCase(service ports.TaskService) *TaskUseCase {
-	return &TaskUseCase{service: service}
+func NewTaskUseCase(service ports.TaskService, projects ports.ProjectService) *TaskUseCase {
+	return &TaskUseCase{service: service, projects: projects}
 }
 
 // CreateTask creates a new task
@@ -33,12 +38,27 @@ func (uc *TaskUseCase) GetTasks(ctx context.Context, filter *models.FilterOption
 	return uc.service.GetTasks(ctx, filter)
 }
 
+// ListTasks retrieves one page of tasks
+func (uc *TaskUseCase) ListTasks(ctx context.Context, filter *models.FilterOptions) (*models.TaskPage, error) {
+	return uc.service.ListTasks(ctx, filter)
+}
+
+// SearchTasks runs a full-text search, most relevant tasks first
+func (uc *TaskUseCase) SearchTasks(ctx context.Context, filter *models.FilterOptions) ([]*models.SearchResult, error) {
+	return uc.service.SearchTasks(ctx, filter)
+}
+
 // UpdateTask updates an existing task
 func (uc *TaskUseCase) UpdateTask(ctx context.Context, req *models.UpdateTaskRequest) (*models.Task, error) {
 	return uc.service.UpdateTask(ctx, req)
 }
 
-// DeleteTask deletes a task by ID
+// PatchTask changes only the fields set in the request
+func (uc *TaskUseCase) PatchTask(ctx context.Context, req *models.PatchTaskRequest) (*models.Task, error) {
+	return uc.service.PatchTask(ctx, req)
+}
+
+// DeleteTask moves a task to the trash
 func (uc *TaskUseCase) DeleteTask(ctx context.Context, id string) error {
 	return uc.service.DeleteTask(ctx, id)
 }
@@ -51,9 +71,8 @@ func (uc *TaskUseCase) ToggleTaskStatus(ctx context.Context, id string) (*models
 // GetTasksByStatus retrieves tasks filtered by status
 func (uc *TaskUseCase) GetTasksByStatus(ctx context.Context, status models.Status) ([]*models.Task, error) {
 	filter := &models.FilterOptions{
-		Status:    &status,
-		SortBy:    "created_at",
-		SortOrder: "desc",
+		Status: &status,
+		Sort:   []models.SortKey{{Field: models.SortCreatedAt, Direction: models.SortDesc}},
 	}
 	return uc.service.GetTasks(ctx, filter)
 }
@@ -61,32 +80,187 @@ func (uc *TaskUseCase) GetTasksByStatus(ctx context.Context, status models.Statu
 // GetTasksByPriority retrieves tasks filtered by priority
 func (uc *TaskUseCase) GetTasksByPriority(ctx context.Context, priority models.Priority) ([]*models.Task, error) {
 	filter := &models.FilterOptions{
-		Priority:  &priority,
-		SortBy:    "created_at",
-		SortOrder: "desc",
+		Priority: &priority,
+		Sort:     []models.SortKey{{Field: models.SortCreatedAt, Direction: models.SortDesc}},
 	}
 	return uc.service.GetTasks(ctx, filter)
 }
 
-// GetTasksByDateRange retrieves tasks within a date range
+// GetTasksByDateRange retrieves tasks due in [from, to), earliest first; before
+// the created, due and updated filters it selected tasks by creation date
 func (uc *TaskUseCase) GetTasksByDateRange(ctx context.Context, from, to time.Time) ([]*models.Task, error) {
 	filter := &models.FilterOptions{
-		DateFrom:  &from,
-		DateTo:    &to,
-		SortBy:    "created_at",
-		SortOrder: "desc",
+		DueFrom: &from,
+		DueTo:   &to,
+		Sort:    []models.SortKey{{Field: models.SortDueDate, Direction: models.SortAsc}},
 	}
 	return uc.service.GetTasks(ctx, filter)
 }
 
-// GetOverdueTasks retrieves overdue tasks
+// GetOverdueTasks retrieves active tasks whose due date has passed
 func (uc *TaskUseCase) GetOverdueTasks(ctx context.Context) ([]*models.Task, error) {
 	now := time.Now()
+	return uc.getActiveDue(ctx, nil, &now)
+}
+
+// GetTasksDueToday retrieves active tasks due today, including the hours already past
+func (uc *TaskUseCase) GetTasksDueToday(ctx context.Context) ([]*models.Task, error) {
+	today := startOfDay(time.Now())
+	tomorrow := today.AddDate(0, 0, 1)
+	return uc.getActiveDue(ctx, &today, &tomorrow)
+}
+
+// GetTasksDueThisWeek retrieves active tasks due from today until the end of
+// the week, which ends on Sunday
+func (uc *TaskUseCase) GetTasksDueThisWeek(ctx context.Context) ([]*models.Task, error) {
+	today := startOfDay(time.Now())
+	daysLeft := (7 - int(today.Weekday())) % 7 // Sunday is weekday 0
+	nextMonday := today.AddDate(0, 0, daysLeft+1)
+	return uc.getActiveDue(ctx, &today, &nextMonday)
+}
+
+// getActiveDue retrieves active tasks due in [from, to), earliest first
+func (uc *TaskUseCase) getActiveDue(ctx context.Context, from, to *time.Time) ([]*models.Task, error) {
+	status := models.StatusActive
 	filter := &models.FilterOptions{
-		Status:    &[]models.Status{models.StatusActive}[0],
-		DateTo:    &now,
-		SortBy:    "due_date",
-		SortOrder: "asc",
+		Status:  &status,
+		DueFrom: from,
+		DueTo:   to,
+		Sort:    []models.SortKey{{Field: models.SortDueDate, Direction: models.SortAsc}},
 	}
 	return uc.service.GetTasks(ctx, filter)
 }
+
+// startOfDay returns midnight of t's day in t's location
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// GetChildren retrieves the direct subtasks of a task
+func (uc *TaskUseCase) GetChildren(ctx context.Context, id string) ([]*models.Task, error) {
+	return uc.service.GetChildren(ctx, id)
+}
+
+// GetSubtree retrieves a task with all of its subtasks
+func (uc *TaskUseCase) GetSubtree(ctx context.Context, id string) (*models.TaskNode, error) {
+	return uc.service.GetSubtree(ctx, id)
+}
+
+// MoveTask moves a task under another parent or to the top level
+func (uc *TaskUseCase) MoveTask(ctx context.Context, req *models.MoveTaskRequest) (*models.Task, error) {
+	return uc.service.MoveTask(ctx, req)
+}
+
+// SetRecurrence sets or clears the recurrence rule of a task
+func (uc *TaskUseCase) SetRecurrence(ctx context.Context, req *models.SetRecurrenceRequest) (*models.Task, error) {
+	return uc.service.SetRecurrence(ctx, req)
+}
+
+// GetTasksByProject retrieves the tasks of a project; an empty ID selects tasks without a project
+func (uc *TaskUseCase) GetTasksByProject(ctx context.Context, projectID string) ([]*models.Task, error) {
+	filter := &models.FilterOptions{
+		ProjectID: &projectID,
+		Sort:      []models.SortKey{{Field: models.SortCreatedAt, Direction: models.SortDesc}},
+	}
+	return uc.service.GetTasks(ctx, filter)
+}
+
+// ListTrash retrieves the tasks in the trash, most recently deleted first
+func (uc *TaskUseCase) ListTrash(ctx context.Context) ([]*models.Task, error) {
+	return uc.service.ListTrash(ctx)
+}
+
+// RestoreTask takes a task and the subtasks deleted with it out of the trash
+func (uc *TaskUseCase) RestoreTask(ctx context.Context, id string) (*models.Task, error) {
+	return uc.service.RestoreTask(ctx, id)
+}
+
+// EmptyTrash permanently deletes every task in the trash
+func (uc *TaskUseCase) EmptyTrash(ctx context.Context) (int, error) {
+	return uc.service.EmptyTrash(ctx)
+}
+
+// Undo reverts the most recent task mutation
+func (uc *TaskUseCase) Undo(ctx context.Context) (*models.HistoryEntry, error) {
+	return uc.service.Undo(ctx)
+}
+
+// Redo applies the most recently undone task mutation again
+func (uc *TaskUseCase) Redo(ctx context.Context) (*models.HistoryEntry, error) {
+	return uc.service.Redo(ctx)
+}
+
+// History lists the task mutations that can be undone and redone
+func (uc *TaskUseCase) History(ctx context.Context) (*models.History, error) {
+	return uc.service.History(ctx)
+}
+
+// GetTaskHistory retrieves the audit log of a task
+func (uc *TaskUseCase) GetTaskHistory(ctx context.Context, id string) ([]*models.TaskEvent, error) {
+	return uc.service.GetTaskHistory(ctx, id)
+}
+
+// ExportTasks collects the tasks matching the filter with their projects and tags for a backup
+func (uc *TaskUseCase) ExportTasks(ctx context.Context, filter *models.FilterOptions) (*models.Backup, error) {
+	return uc.service.ExportTasks(ctx, filter)
+}
+
+// ImportTasks creates or replaces tasks read from a file, or reports what it would do for a dry run
+func (uc *TaskUseCase) ImportTasks(ctx context.Context, data *models.Backup, opts *models.ImportOptions) (*models.ImportResult, error) {
+	return uc.service.ImportTasks(ctx, data, opts)
+}
+
+// ExportTodoTxt writes the tasks matching the filter in the todo.txt format
+func (uc *TaskUseCase) ExportTodoTxt(ctx context.Context, w io.Writer, filter *models.FilterOptions) error {
+	tasks, err := uc.service.GetTasks(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	projects, err := uc.projects.GetProjects(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	if err := todotxt.Encode(w, tasks, projects); err != nil {
+		return fmt.Errorf("failed to encode todo.txt: %w", err)
+	}
+	return nil
+}
+
+// ImportTodoTxt creates tasks from the lines of a todo.txt file; a "+project"
+// refers to an existing project by name
+func (uc *TaskUseCase) ImportTodoTxt(ctx context.Context, r io.Reader) (*models.ImportResult, error) {
+	projects, err := uc.projects.GetProjects(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := todotxt.Decode(r, projects)
+	if err != nil {
+		return nil, domain.Invalid("", "invalid todo.txt format: %v", err)
+	}
+
+	return uc.service.ImportTasks(ctx, &models.Backup{Tasks: tasks}, nil)
+}
+
+// GetReminders retrieves the reminders of a task
+func (uc *TaskUseCase) GetReminders(ctx context.Context, taskID string) ([]*models.Reminder, error) {
+	return uc.service.GetReminders(ctx, taskID)
+}
+
+// SetReminders replaces the reminders of a task
+func (uc *TaskUseCase) SetReminders(ctx context.Context, req *models.SetRemindersRequest) ([]*models.Reminder, error) {
+	return uc.service.SetReminders(ctx, req)
+}
+
+// SnoozeReminder postpones a reminder
+func (uc *TaskUseCase) SnoozeReminder(ctx context.Context, req *models.SnoozeReminderRequest) (*models.Reminder, error) {
+	return uc.service.SnoozeReminder(ctx, req)
+}
+
+// DismissReminder silences a reminder
+func (uc *TaskUseCase) DismissReminder(ctx context.Context, id string) (*models.Reminder, error) {
+	return uc.service.DismissReminder(ctx, id)
+}